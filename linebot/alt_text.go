@@ -0,0 +1,120 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+// maxAltTextLength is the maximum number of characters in altText
+const maxAltTextLength = 400
+
+// WithAutoAltText function
+// Once set, Flex and template messages sent without altText get one generated
+// from their first text, such as the first text component of a Flex message.
+func WithAutoAltText() ClientOption {
+	return func(client *Client) error {
+		client.autoAltText = true
+		return nil
+	}
+}
+
+// messagesToSend returns `messages` with generated altText if enabled.
+// The given messages are not modified.
+func (client *Client) messagesToSend(messages []Message) []Message {
+	if !client.autoAltText {
+		return messages
+	}
+	result := make([]Message, len(messages))
+	for i, m := range messages {
+		result[i] = m
+		switch m := m.(type) {
+		case *FlexMessage:
+			if m.AltText == "" {
+				copied := *m
+				copied.AltText = truncateAltText(flexContainerText(m.Contents))
+				result[i] = &copied
+			}
+		case *TemplateMessage:
+			if m.AltText == "" {
+				copied := *m
+				copied.AltText = truncateAltText(templateText(m.Template))
+				result[i] = &copied
+			}
+		}
+	}
+	return result
+}
+
+func flexContainerText(container FlexContainer) string {
+	switch c := container.(type) {
+	case *BubbleContainer:
+		for _, box := range []*BoxComponent{c.Header, c.Body, c.Footer} {
+			if box == nil {
+				continue
+			}
+			if text := flexComponentText(box); text != "" {
+				return text
+			}
+		}
+	case *CarouselContainer:
+		for _, bubble := range c.Contents {
+			if text := flexContainerText(bubble); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+func flexComponentText(component FlexComponent) string {
+	switch c := component.(type) {
+	case *TextComponent:
+		return c.Text
+	case *BoxComponent:
+		for _, content := range c.Contents {
+			if text := flexComponentText(content); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+func templateText(template Template) string {
+	switch t := template.(type) {
+	case *ButtonsTemplate:
+		if t.Title != "" {
+			return t.Title
+		}
+		return t.Text
+	case *ConfirmTemplate:
+		return t.Text
+	case *CarouselTemplate:
+		for _, column := range t.Columns {
+			if column.Title != "" {
+				return column.Title
+			}
+			if column.Text != "" {
+				return column.Text
+			}
+		}
+	}
+	return ""
+}
+
+func truncateAltText(text string) string {
+	runes := []rune(text)
+	if len(runes) > maxAltTextLength {
+		return string(runes[:maxAltTextLength])
+	}
+	return text
+}
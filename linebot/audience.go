@@ -0,0 +1,496 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// AudienceGroupStatus type
+type AudienceGroupStatus string
+
+// AudienceGroupStatus constants
+const (
+	AudienceGroupStatusInProgress AudienceGroupStatus = "IN_PROGRESS"
+	AudienceGroupStatusReady      AudienceGroupStatus = "READY"
+	AudienceGroupStatusFailed     AudienceGroupStatus = "FAILED"
+	AudienceGroupStatusExpired    AudienceGroupStatus = "EXPIRED"
+	AudienceGroupStatusInactive   AudienceGroupStatus = "INACTIVE"
+	AudienceGroupStatusActivating AudienceGroupStatus = "ACTIVATING"
+)
+
+// AudienceGroupJobStatus type
+type AudienceGroupJobStatus string
+
+// AudienceGroupJobStatus constants
+const (
+	AudienceGroupJobStatusQueued   AudienceGroupJobStatus = "QUEUED"
+	AudienceGroupJobStatusWorking  AudienceGroupJobStatus = "WORKING"
+	AudienceGroupJobStatusFinished AudienceGroupJobStatus = "FINISHED"
+	AudienceGroupJobStatusFailed   AudienceGroupJobStatus = "FAILED"
+)
+
+// AudienceAuthorityLevel type
+type AudienceAuthorityLevel string
+
+// AudienceAuthorityLevel constants
+const (
+	AudienceAuthorityLevelPublic  AudienceAuthorityLevel = "PUBLIC"
+	AudienceAuthorityLevelPrivate AudienceAuthorityLevel = "PRIVATE"
+)
+
+// AudienceGroup type
+// `Created` is the time of creation in milliseconds since the epoch.
+type AudienceGroup struct {
+	AudienceGroupID int                 `json:"audienceGroupId"`
+	Type            string              `json:"type"`
+	Description     string              `json:"description"`
+	Status          AudienceGroupStatus `json:"status"`
+	FailedType      string              `json:"failedType,omitempty"`
+	AudienceCount   int                 `json:"audienceCount"`
+	Created         int64               `json:"created"`
+	Permission      string              `json:"permission"`
+	IsIfaAudience   bool                `json:"isIfaAudience"`
+}
+
+// AudienceGroupJob type
+// A job is created for each upload to an audience group.
+type AudienceGroupJob struct {
+	AudienceGroupJobID int                    `json:"audienceGroupJobId"`
+	AudienceGroupID    int                    `json:"audienceGroupId"`
+	Description        string                 `json:"description"`
+	Type               string                 `json:"type"`
+	JobStatus          AudienceGroupJobStatus `json:"jobStatus"`
+	FailedType         string                 `json:"failedType,omitempty"`
+	AudienceCount      int                    `json:"audienceCount"`
+	Created            int64                  `json:"created"`
+}
+
+// maxAudiencesPerRequest is the maximum number of user IDs uploaded in a request
+const maxAudiencesPerRequest = 10000
+
+type audience struct {
+	ID string `json:"id"`
+}
+
+func newAudiences(ids []string) []audience {
+	audiences := make([]audience, len(ids))
+	for i, id := range ids {
+		audiences[i] = audience{ID: id}
+	}
+	return audiences
+}
+
+// CreateUploadAudienceGroup method
+// `audiences` are user IDs, or IFAs if `isIFAAudience` is true. Up to 10,000
+// can be given; add more with AddAudiences once the audience group is created.
+func (client *Client) CreateUploadAudienceGroup(description string, isIFAAudience bool, audiences []string) *CreateUploadAudienceGroupCall {
+	return &CreateUploadAudienceGroupCall{
+		c:             client,
+		description:   description,
+		isIFAAudience: isIFAAudience,
+		audiences:     audiences,
+	}
+}
+
+// CreateUploadAudienceGroupCall type
+type CreateUploadAudienceGroupCall struct {
+	c   *Client
+	ctx context.Context
+
+	description       string
+	isIFAAudience     bool
+	audiences         []string
+	uploadDescription string
+}
+
+// WithContext method
+func (call *CreateUploadAudienceGroupCall) WithContext(ctx context.Context) *CreateUploadAudienceGroupCall {
+	call.ctx = ctx
+	return call
+}
+
+// WithUploadDescription method
+func (call *CreateUploadAudienceGroupCall) WithUploadDescription(uploadDescription string) *CreateUploadAudienceGroupCall {
+	call.uploadDescription = uploadDescription
+	return call
+}
+
+func (call *CreateUploadAudienceGroupCall) encodeJSON(w io.Writer) error {
+	return call.c.encodeJSON(w, &struct {
+		Description       string     `json:"description"`
+		IsIfaAudience     bool       `json:"isIfaAudience"`
+		UploadDescription string     `json:"uploadDescription,omitempty"`
+		Audiences         []audience `json:"audiences,omitempty"`
+	}{
+		Description:       call.description,
+		IsIfaAudience:     call.isIFAAudience,
+		UploadDescription: call.uploadDescription,
+		Audiences:         newAudiences(call.audiences),
+	})
+}
+
+// Do method
+func (call *CreateUploadAudienceGroupCall) Do() (*CreateAudienceGroupResponse, error) {
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	res, err := call.c.post(call.ctx, APIEndpointCreateUploadAudienceGroup, buf.Bytes())
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToCreateAudienceGroupResponse(res, call.c.codec)
+}
+
+// AddAudiences method
+// Any number of user IDs can be given. They are uploaded in multiple requests
+// if there are more than the API accepts in a request.
+func (client *Client) AddAudiences(audienceGroupID int, audiences ...string) *AddAudiencesCall {
+	return &AddAudiencesCall{
+		c:               client,
+		audienceGroupID: audienceGroupID,
+		audiences:       audiences,
+	}
+}
+
+// AddAudiencesCall type
+type AddAudiencesCall struct {
+	c   *Client
+	ctx context.Context
+
+	audienceGroupID   int
+	audiences         []string
+	uploadDescription string
+}
+
+// WithContext method
+func (call *AddAudiencesCall) WithContext(ctx context.Context) *AddAudiencesCall {
+	call.ctx = ctx
+	return call
+}
+
+// WithUploadDescription method
+func (call *AddAudiencesCall) WithUploadDescription(uploadDescription string) *AddAudiencesCall {
+	call.uploadDescription = uploadDescription
+	return call
+}
+
+func (call *AddAudiencesCall) encodeJSON(w io.Writer, audiences []string) error {
+	return call.c.encodeJSON(w, &struct {
+		AudienceGroupID   int        `json:"audienceGroupId"`
+		UploadDescription string     `json:"uploadDescription,omitempty"`
+		Audiences         []audience `json:"audiences"`
+	}{
+		AudienceGroupID:   call.audienceGroupID,
+		UploadDescription: call.uploadDescription,
+		Audiences:         newAudiences(audiences),
+	})
+}
+
+// Do method
+// It stops at the first failed request. The user IDs in the preceding requests have been added.
+func (call *AddAudiencesCall) Do() (*BasicResponse, error) {
+	if len(call.audiences) == 0 {
+		return nil, &ValidationError{Field: "audiences", Reason: "must not be empty"}
+	}
+	var result *BasicResponse
+	for start := 0; start < len(call.audiences); start += maxAudiencesPerRequest {
+		end := start + maxAudiencesPerRequest
+		if end > len(call.audiences) {
+			end = len(call.audiences)
+		}
+		res, err := call.do(call.audiences[start:end])
+		if err != nil {
+			return nil, err
+		}
+		result = res
+	}
+	return result, nil
+}
+
+func (call *AddAudiencesCall) do(audiences []string) (*BasicResponse, error) {
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf, audiences); err != nil {
+		return nil, err
+	}
+	res, err := call.c.put(call.ctx, APIEndpointAddAudiences, buf.Bytes())
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// GetAudienceGroup method
+func (client *Client) GetAudienceGroup(audienceGroupID int) *GetAudienceGroupCall {
+	return &GetAudienceGroupCall{
+		c:               client,
+		audienceGroupID: audienceGroupID,
+	}
+}
+
+// GetAudienceGroupCall type
+type GetAudienceGroupCall struct {
+	c   *Client
+	ctx context.Context
+
+	audienceGroupID int
+}
+
+// WithContext method
+func (call *GetAudienceGroupCall) WithContext(ctx context.Context) *GetAudienceGroupCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetAudienceGroupCall) Do() (*AudienceGroupResponse, error) {
+	endpoint := fmt.Sprintf(APIEndpointGetAudienceGroup, call.audienceGroupID)
+	res, err := call.c.get(call.ctx, endpoint, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToAudienceGroupResponse(res, call.c.codec)
+}
+
+// GetAudienceGroups method
+// `page` starts from 1. `size` is the number of audience groups per page, and
+// the API default is used if it is 0. Empty `description` and `status` don't
+// filter the audience groups; `description` matches a part of the description.
+func (client *Client) GetAudienceGroups(page, size int, description string, status AudienceGroupStatus) *GetAudienceGroupsCall {
+	return &GetAudienceGroupsCall{
+		c:           client,
+		page:        page,
+		size:        size,
+		description: description,
+		status:      status,
+	}
+}
+
+// GetAudienceGroupsCall type
+type GetAudienceGroupsCall struct {
+	c   *Client
+	ctx context.Context
+
+	page        int
+	size        int
+	description string
+	status      AudienceGroupStatus
+}
+
+// WithContext method
+func (call *GetAudienceGroupsCall) WithContext(ctx context.Context) *GetAudienceGroupsCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetAudienceGroupsCall) Do() (*AudienceGroupsResponse, error) {
+	q := url.Values{}
+	q.Set("page", strconv.Itoa(call.page))
+	if call.size != 0 {
+		q.Set("size", strconv.Itoa(call.size))
+	}
+	if call.description != "" {
+		q.Set("description", call.description)
+	}
+	if call.status != "" {
+		q.Set("status", string(call.status))
+	}
+	res, err := call.c.get(call.ctx, APIEndpointGetAudienceGroups, q)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToAudienceGroupsResponse(res, call.c.codec)
+}
+
+// DeleteAudienceGroup method
+func (client *Client) DeleteAudienceGroup(audienceGroupID int) *DeleteAudienceGroupCall {
+	return &DeleteAudienceGroupCall{
+		c:               client,
+		audienceGroupID: audienceGroupID,
+	}
+}
+
+// DeleteAudienceGroupCall type
+type DeleteAudienceGroupCall struct {
+	c   *Client
+	ctx context.Context
+
+	audienceGroupID int
+}
+
+// WithContext method
+func (call *DeleteAudienceGroupCall) WithContext(ctx context.Context) *DeleteAudienceGroupCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *DeleteAudienceGroupCall) Do() (*BasicResponse, error) {
+	endpoint := fmt.Sprintf(APIEndpointDeleteAudienceGroup, call.audienceGroupID)
+	res, err := call.c.delete(call.ctx, endpoint)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// UpdateAudienceGroupDescription method
+func (client *Client) UpdateAudienceGroupDescription(audienceGroupID int, description string) *UpdateAudienceGroupDescriptionCall {
+	return &UpdateAudienceGroupDescriptionCall{
+		c:               client,
+		audienceGroupID: audienceGroupID,
+		description:     description,
+	}
+}
+
+// UpdateAudienceGroupDescriptionCall type
+type UpdateAudienceGroupDescriptionCall struct {
+	c   *Client
+	ctx context.Context
+
+	audienceGroupID int
+	description     string
+}
+
+// WithContext method
+func (call *UpdateAudienceGroupDescriptionCall) WithContext(ctx context.Context) *UpdateAudienceGroupDescriptionCall {
+	call.ctx = ctx
+	return call
+}
+
+func (call *UpdateAudienceGroupDescriptionCall) encodeJSON(w io.Writer) error {
+	return call.c.encodeJSON(w, &struct {
+		Description string `json:"description"`
+	}{
+		Description: call.description,
+	})
+}
+
+// Do method
+func (call *UpdateAudienceGroupDescriptionCall) Do() (*BasicResponse, error) {
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(APIEndpointUpdateAudienceGroupDescription, call.audienceGroupID)
+	res, err := call.c.put(call.ctx, endpoint, buf.Bytes())
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// GetAudienceGroupAuthorityLevel method
+func (client *Client) GetAudienceGroupAuthorityLevel() *GetAudienceGroupAuthorityLevelCall {
+	return &GetAudienceGroupAuthorityLevelCall{
+		c: client,
+	}
+}
+
+// GetAudienceGroupAuthorityLevelCall type
+type GetAudienceGroupAuthorityLevelCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetAudienceGroupAuthorityLevelCall) WithContext(ctx context.Context) *GetAudienceGroupAuthorityLevelCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetAudienceGroupAuthorityLevelCall) Do() (*AudienceAuthorityLevelResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointAudienceGroupAuthorityLevel, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToAudienceAuthorityLevelResponse(res, call.c.codec)
+}
+
+// ChangeAudienceGroupAuthorityLevel method
+func (client *Client) ChangeAudienceGroupAuthorityLevel(authorityLevel AudienceAuthorityLevel) *ChangeAudienceGroupAuthorityLevelCall {
+	return &ChangeAudienceGroupAuthorityLevelCall{
+		c:              client,
+		authorityLevel: authorityLevel,
+	}
+}
+
+// ChangeAudienceGroupAuthorityLevelCall type
+type ChangeAudienceGroupAuthorityLevelCall struct {
+	c   *Client
+	ctx context.Context
+
+	authorityLevel AudienceAuthorityLevel
+}
+
+// WithContext method
+func (call *ChangeAudienceGroupAuthorityLevelCall) WithContext(ctx context.Context) *ChangeAudienceGroupAuthorityLevelCall {
+	call.ctx = ctx
+	return call
+}
+
+func (call *ChangeAudienceGroupAuthorityLevelCall) encodeJSON(w io.Writer) error {
+	return call.c.encodeJSON(w, &struct {
+		AuthorityLevel AudienceAuthorityLevel `json:"authorityLevel"`
+	}{
+		AuthorityLevel: call.authorityLevel,
+	})
+}
+
+// Do method
+func (call *ChangeAudienceGroupAuthorityLevelCall) Do() (*BasicResponse, error) {
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	res, err := call.c.put(call.ctx, APIEndpointAudienceGroupAuthorityLevel, buf.Bytes())
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
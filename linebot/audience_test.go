@@ -0,0 +1,314 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAudience(t *testing.T) {
+	type want struct {
+		Method      string
+		URLPath     string
+		RawQuery    string
+		RequestBody []byte
+		Response    interface{}
+	}
+	var testCases = []struct {
+		Do       func(*Client) (interface{}, error)
+		Response []byte
+		Want     want
+	}{
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.CreateUploadAudienceGroup("audienceGroupName", false, []string{"U4af4980627", "U4af4980628"}).WithUploadDescription("fileName").Do()
+			},
+			Response: []byte(`{"audienceGroupId":4389303728991,"type":"UPLOAD","description":"audienceGroupName","created":1613698278,"permission":"READ_WRITE","expireTimestamp":1629250278,"isIfaAudience":false}`),
+			Want: want{
+				Method:      http.MethodPost,
+				URLPath:     APIEndpointCreateUploadAudienceGroup,
+				RequestBody: []byte(`{"description":"audienceGroupName","isIfaAudience":false,"uploadDescription":"fileName","audiences":[{"id":"U4af4980627"},{"id":"U4af4980628"}]}` + "\n"),
+				Response: &CreateAudienceGroupResponse{
+					AudienceGroupID: 4389303728991,
+					Type:            "UPLOAD",
+					Description:     "audienceGroupName",
+					Created:         1613698278,
+					Permission:      "READ_WRITE",
+					ExpireTimestamp: 1629250278,
+				},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.AddAudiences(4389303728991, "U4af4980627", "U4af4980628").WithUploadDescription("fileName").Do()
+			},
+			Response: []byte(`{}`),
+			Want: want{
+				Method:      http.MethodPut,
+				URLPath:     APIEndpointAddAudiences,
+				RequestBody: []byte(`{"audienceGroupId":4389303728991,"uploadDescription":"fileName","audiences":[{"id":"U4af4980627"},{"id":"U4af4980628"}]}` + "\n"),
+				Response:    &BasicResponse{},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.GetAudienceGroup(4389303728991).Do()
+			},
+			Response: []byte(`{"audienceGroup":{"audienceGroupId":4389303728991,"type":"UPLOAD","description":"audienceGroupName","status":"IN_PROGRESS","audienceCount":1887,"created":1608619802,"permission":"READ","isIfaAudience":false},"jobs":[{"audienceGroupJobId":12345678,"audienceGroupId":4389303728991,"description":"audience_list.txt","type":"DIFF_ADD","jobStatus":"FINISHED","audienceCount":1887,"created":1608619802},{"audienceGroupJobId":12345679,"audienceGroupId":4389303728991,"description":"audience_list_2.txt","type":"DIFF_ADD","jobStatus":"WORKING","audienceCount":0,"created":1608619902}]}`),
+			Want: want{
+				Method:      http.MethodGet,
+				URLPath:     fmt.Sprintf(APIEndpointGetAudienceGroup, 4389303728991),
+				RequestBody: []byte(""),
+				Response: &AudienceGroupResponse{
+					AudienceGroup: &AudienceGroup{
+						AudienceGroupID: 4389303728991,
+						Type:            "UPLOAD",
+						Description:     "audienceGroupName",
+						Status:          AudienceGroupStatusInProgress,
+						AudienceCount:   1887,
+						Created:         1608619802,
+						Permission:      "READ",
+					},
+					Jobs: []*AudienceGroupJob{
+						{
+							AudienceGroupJobID: 12345678,
+							AudienceGroupID:    4389303728991,
+							Description:        "audience_list.txt",
+							Type:               "DIFF_ADD",
+							JobStatus:          AudienceGroupJobStatusFinished,
+							AudienceCount:      1887,
+							Created:            1608619802,
+						},
+						{
+							AudienceGroupJobID: 12345679,
+							AudienceGroupID:    4389303728991,
+							Description:        "audience_list_2.txt",
+							Type:               "DIFF_ADD",
+							JobStatus:          AudienceGroupJobStatusWorking,
+							Created:            1608619902,
+						},
+					},
+				},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.GetAudienceGroups(2, 40, "audience", AudienceGroupStatusReady).Do()
+			},
+			Response: []byte(`{"audienceGroups":[{"audienceGroupId":4389303728991,"type":"UPLOAD","description":"audienceGroupName","status":"READY","audienceCount":1887,"created":1608619802,"permission":"READ_WRITE","isIfaAudience":false}],"hasNextPage":false,"totalCount":41,"readWriteAudienceGroupTotalCount":41,"page":2,"size":40}`),
+			Want: want{
+				Method:      http.MethodGet,
+				URLPath:     APIEndpointGetAudienceGroups,
+				RawQuery:    "description=audience&page=2&size=40&status=READY",
+				RequestBody: []byte(""),
+				Response: &AudienceGroupsResponse{
+					AudienceGroups: []*AudienceGroup{
+						{
+							AudienceGroupID: 4389303728991,
+							Type:            "UPLOAD",
+							Description:     "audienceGroupName",
+							Status:          AudienceGroupStatusReady,
+							AudienceCount:   1887,
+							Created:         1608619802,
+							Permission:      "READ_WRITE",
+						},
+					},
+					TotalCount:                       41,
+					ReadWriteAudienceGroupTotalCount: 41,
+					Page:                             2,
+					Size:                             40,
+				},
+			},
+		},
+		{
+			// Without filters
+			Do: func(client *Client) (interface{}, error) {
+				return client.GetAudienceGroups(1, 0, "", "").Do()
+			},
+			Response: []byte(`{"audienceGroups":[],"hasNextPage":false,"totalCount":0,"readWriteAudienceGroupTotalCount":0,"page":1,"size":20}`),
+			Want: want{
+				Method:      http.MethodGet,
+				URLPath:     APIEndpointGetAudienceGroups,
+				RawQuery:    "page=1",
+				RequestBody: []byte(""),
+				Response: &AudienceGroupsResponse{
+					AudienceGroups: []*AudienceGroup{},
+					Page:           1,
+					Size:           20,
+				},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.DeleteAudienceGroup(4389303728991).Do()
+			},
+			Response: []byte(`{}`),
+			Want: want{
+				Method:      http.MethodDelete,
+				URLPath:     fmt.Sprintf(APIEndpointDeleteAudienceGroup, 4389303728991),
+				RequestBody: []byte(""),
+				Response:    &BasicResponse{},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.UpdateAudienceGroupDescription(4389303728991, "audienceGroupName").Do()
+			},
+			Response: []byte(`{}`),
+			Want: want{
+				Method:      http.MethodPut,
+				URLPath:     fmt.Sprintf(APIEndpointUpdateAudienceGroupDescription, 4389303728991),
+				RequestBody: []byte(`{"description":"audienceGroupName"}` + "\n"),
+				Response:    &BasicResponse{},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.GetAudienceGroupAuthorityLevel().Do()
+			},
+			Response: []byte(`{"authorityLevel":"PUBLIC"}`),
+			Want: want{
+				Method:      http.MethodGet,
+				URLPath:     APIEndpointAudienceGroupAuthorityLevel,
+				RequestBody: []byte(""),
+				Response: &AudienceAuthorityLevelResponse{
+					AuthorityLevel: AudienceAuthorityLevelPublic,
+				},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.ChangeAudienceGroupAuthorityLevel(AudienceAuthorityLevelPrivate).Do()
+			},
+			Response: []byte(`{}`),
+			Want: want{
+				Method:      http.MethodPut,
+				URLPath:     APIEndpointAudienceGroupAuthorityLevel,
+				RequestBody: []byte(`{"authorityLevel":"PRIVATE"}` + "\n"),
+				Response:    &BasicResponse{},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != tc.Want.Method {
+			t.Errorf("Method %s; want %s", r.Method, tc.Want.Method)
+		}
+		if r.URL.Path != tc.Want.URLPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, tc.Want.URLPath)
+		}
+		if r.URL.RawQuery != tc.Want.RawQuery {
+			t.Errorf("RawQuery %s; want %s", r.URL.RawQuery, tc.Want.RawQuery)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.Want.RequestBody) {
+			t.Errorf("RequestBody %s; want %s", body, tc.Want.RequestBody)
+		}
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := tc.Do(client)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestAddAudiencesChunked(t *testing.T) {
+	var got []int
+	var gotIDs []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		request := struct {
+			AudienceGroupID int `json:"audienceGroupId"`
+			Audiences       []struct {
+				ID string `json:"id"`
+			} `json:"audiences"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+			return
+		}
+		if request.AudienceGroupID != 4389303728991 {
+			t.Errorf("AudienceGroupID %d; want %d", request.AudienceGroupID, 4389303728991)
+		}
+		got = append(got, len(request.Audiences))
+		for _, a := range request.Audiences {
+			gotIDs = append(gotIDs, a.ID)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 25000)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("U%032x", i)
+	}
+	if _, err := client.AddAudiences(4389303728991, ids...).Do(); err != nil {
+		t.Fatal(err)
+	}
+	want := []int{10000, 10000, 5000}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Audiences per request %v; want %v", got, want)
+	}
+	if !reflect.DeepEqual(gotIDs, ids) {
+		t.Errorf("Audiences are not uploaded in order")
+	}
+}
+
+func TestAddAudiencesEmpty(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.AddAudiences(4389303728991).Do()
+	want := &ValidationError{Field: "audiences", Reason: "must not be empty"}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Error %v; want %v", err, want)
+	}
+	if requests != 0 {
+		t.Errorf("requests %d; want 0", requests)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+// Bounds type
+// It is the rectangle of an imagemap area or a rich menu area.
+type Bounds struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Validate method of Bounds
+func (b Bounds) Validate() error {
+	switch {
+	case b.X < 0:
+		return &ValidationError{Field: "x", Reason: "must not be negative"}
+	case b.Y < 0:
+		return &ValidationError{Field: "y", Reason: "must not be negative"}
+	case b.Width <= 0:
+		return &ValidationError{Field: "width", Reason: "must be positive"}
+	case b.Height <= 0:
+		return &ValidationError{Field: "height", Reason: "must be positive"}
+	}
+	return nil
+}
+
+// ValidateWithin method of Bounds
+// It also checks that the rectangle fits in an image of the given size.
+func (b Bounds) ValidateWithin(width, height int) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	if b.X+b.Width > width {
+		return &ValidationError{Field: "width", Reason: "exceeds the image width"}
+	}
+	if b.Y+b.Height > height {
+		return &ValidationError{Field: "height", Reason: "exceeds the image height"}
+	}
+	return nil
+}
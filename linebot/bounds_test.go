@@ -0,0 +1,96 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBoundsMarshaling(t *testing.T) {
+	bounds := Bounds{X: 520, Y: 0, Width: 520, Height: 1040}
+	imagemap, err := json.Marshal(NewURIImagemapAction("https://example.com/", ImagemapArea(bounds)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	richMenu, err := json.Marshal(&AreaDetail{
+		Bounds: bounds,
+		Action: RichMenuAction{Type: RichMenuActionTypeURI, URI: "https://example.com/"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotImagemap struct {
+		Area json.RawMessage `json:"area"`
+	}
+	if err := json.Unmarshal(imagemap, &gotImagemap); err != nil {
+		t.Fatal(err)
+	}
+	var gotRichMenu struct {
+		Bounds json.RawMessage `json:"bounds"`
+	}
+	if err := json.Unmarshal(richMenu, &gotRichMenu); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"x":520,"y":0,"width":520,"height":1040}`
+	if string(gotImagemap.Area) != want {
+		t.Errorf("imagemap area %s; want %s", gotImagemap.Area, want)
+	}
+	if string(gotRichMenu.Bounds) != want {
+		t.Errorf("rich menu bounds %s; want %s", gotRichMenu.Bounds, want)
+	}
+}
+
+func TestBoundsValidate(t *testing.T) {
+	var testCases = []struct {
+		Bounds Bounds
+		Want   error
+	}{
+		{
+			Bounds: Bounds{X: 0, Y: 0, Width: 1040, Height: 1040},
+		},
+		{
+			Bounds: Bounds{X: -1, Y: 0, Width: 1040, Height: 1040},
+			Want:   &ValidationError{Field: "x", Reason: "must not be negative"},
+		},
+		{
+			Bounds: Bounds{X: 0, Y: 0, Width: 0, Height: 1040},
+			Want:   &ValidationError{Field: "width", Reason: "must be positive"},
+		},
+		{
+			Bounds: Bounds{X: 520, Y: 0, Width: 1040, Height: 1040},
+			Want:   &ValidationError{Field: "width", Reason: "exceeds the image width"},
+		},
+		{
+			Bounds: Bounds{X: 0, Y: 520, Width: 1040, Height: 1040},
+			Want:   &ValidationError{Field: "height", Reason: "exceeds the image height"},
+		},
+	}
+	for i, tc := range testCases {
+		err := tc.Bounds.ValidateWithin(1040, 1040)
+		if !reflect.DeepEqual(err, tc.Want) {
+			t.Errorf("ValidateWithin %d %v; want %v", i, err, tc.Want)
+		}
+	}
+}
+
+func TestImagemapAreaValidate(t *testing.T) {
+	area := ImagemapArea{X: 520, Y: 0, Width: 1040, Height: 1040}
+	want := &ValidationError{Field: "width", Reason: "exceeds the image width"}
+	if err := area.ValidateWithin(1040, 1040); !reflect.DeepEqual(err, want) {
+		t.Errorf("ValidateWithin %v; want %v", err, want)
+	}
+}
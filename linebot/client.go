@@ -15,11 +15,15 @@
 package linebot
 
 import (
+	"bytes"
+	"crypto/rand"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
+	"time"
 
 	"golang.org/x/net/context"
 	"golang.org/x/net/context/ctxhttp"
@@ -29,30 +33,72 @@ import (
 const (
 	APIEndpointBase = "https://api.line.me"
 
-	APIEndpointPushMessage       = "/v2/bot/message/push"
-	APIEndpointReplyMessage      = "/v2/bot/message/reply"
-	APIEndpointGetMessageContent = "/v2/bot/message/%s/content"
-	APIEndpointLeaveGroup        = "/v2/bot/group/%s/leave"
-	APIEndpointLeaveRoom         = "/v2/bot/room/%s/leave"
-	APIEndpointGetProfile        = "/v2/bot/profile/%s"
+	APIEndpointPushMessage                    = "/v2/bot/message/push"
+	APIEndpointReplyMessage                   = "/v2/bot/message/reply"
+	APIEndpointGetMessageContent              = "/v2/bot/message/%s/content"
+	APIEndpointLeaveGroup                     = "/v2/bot/group/%s/leave"
+	APIEndpointLeaveRoom                      = "/v2/bot/room/%s/leave"
+	APIEndpointGetProfile                     = "/v2/bot/profile/%s"
+	APIEndpointCreateRichMenu                 = "/v2/bot/richmenu"
+	APIEndpointGetRichMenu                    = "/v2/bot/richmenu/%s"
+	APIEndpointDeleteRichMenu                 = "/v2/bot/richmenu/%s"
+	APIEndpointListRichMenu                   = "/v2/bot/richmenu/list"
+	APIEndpointUploadRichMenuImage            = "/v2/bot/richmenu/%s/content"
+	APIEndpointLinkUserRichMenu               = "/v2/bot/user/%s/richmenu/%s"
+	APIEndpointUnlinkUserRichMenu             = "/v2/bot/user/%s/richmenu"
+	APIEndpointSetDefaultRichMenu             = "/v2/bot/user/all/richmenu/%s"
+	APIEndpointDefaultRichMenu                = "/v2/bot/user/all/richmenu"
+	APIEndpointGetNumberReplyMessages         = "/v2/bot/message/delivery/reply"
+	APIEndpointGetNumberPushMessages          = "/v2/bot/message/delivery/push"
+	APIEndpointGetNumberMulticastMessages     = "/v2/bot/message/delivery/multicast"
+	APIEndpointGetNumberBroadcastMessages     = "/v2/bot/message/delivery/broadcast"
+	APIEndpointGetMessageQuota                = "/v2/bot/message/quota"
+	APIEndpointGetMessageQuotaConsumption     = "/v2/bot/message/quota/consumption"
+	APIEndpointGetBotInfo                     = "/v2/bot/info"
+	APIEndpointWebhookEndpoint                = "/v2/bot/channel/webhook/endpoint"
+	APIEndpointTestWebhookEndpoint            = "/v2/bot/channel/webhook/test"
+	APIEndpointIssueLinkToken                 = "/v2/bot/user/%s/linkToken"
+	APIEndpointAddAudiences                   = "/v2/bot/audienceGroup/upload"
+	APIEndpointGetAudienceGroup               = "/v2/bot/audienceGroup/%d"
+	APIEndpointUpdateAudienceGroupDescription = "/v2/bot/audienceGroup/%d/updateDescription"
+	APIEndpointAudienceGroupAuthorityLevel    = "/v2/bot/audienceGroup/authorityLevel"
+	APIEndpointMulticast                      = "/v2/bot/message/multicast"
+	APIEndpointGetFriendDemographics          = "/v2/bot/insight/demographic"
+	APIEndpointGetNumberOfMessageDeliveries   = "/v2/bot/insight/message/delivery"
+	APIEndpointGetNumberOfFollowers           = "/v2/bot/insight/followers"
+	APIEndpointGetUserInteractionStatistics   = "/v2/bot/insight/message/event"
+	APIEndpointCreateUploadAudienceGroup      = "/v2/bot/audienceGroup/upload"
+	APIEndpointGetAudienceGroups              = "/v2/bot/audienceGroup/list"
+	APIEndpointDeleteAudienceGroup            = "/v2/bot/audienceGroup/%d"
 )
 
 // Client type
 type Client struct {
 	channelSecret string
 	channelToken  string
+	channelID     string       // sent as X-Line-ChannelId when set
 	endpointBase  *url.URL     // default APIEndpointBase
 	httpClient    *http.Client // default http.DefaultClient
+	metrics       MetricsFunc
+	logger        Logger
+	redactLogs    bool
+	autoAltText   bool
+	replyTokens   *replyTokenSet
+	stickerCheck  bool
+	codec         JSONCodec
+	retries       int
+	retryBackoff  time.Duration
+	random        io.Reader // default crypto/rand.Reader, for retry keys
+	limiter       *rateLimiter
+	semaphore     chan struct{} // shared by the fan-out helpers
 }
 
 // ClientOption type
 type ClientOption func(*Client) error
 
 // New returns a new bot client instance.
+// `channelSecret` can be empty if it is given by WithChannelSecret.
 func New(channelSecret, channelToken string, options ...ClientOption) (*Client, error) {
-	if channelSecret == "" {
-		return nil, errors.New("missing channel secret")
-	}
 	if channelToken == "" {
 		return nil, errors.New("missing channel access token")
 	}
@@ -60,6 +106,8 @@ func New(channelSecret, channelToken string, options ...ClientOption) (*Client,
 		channelSecret: channelSecret,
 		channelToken:  channelToken,
 		httpClient:    http.DefaultClient,
+		codec:         stdJSONCodec{},
+		random:        rand.Reader,
 	}
 	for _, option := range options {
 		err := option(c)
@@ -67,6 +115,9 @@ func New(channelSecret, channelToken string, options ...ClientOption) (*Client,
 			return nil, err
 		}
 	}
+	if c.channelSecret == "" {
+		return nil, errors.New("missing channel secret")
+	}
 	if c.endpointBase == nil {
 		u, err := url.ParseRequestURI(APIEndpointBase)
 		if err != nil {
@@ -85,6 +136,24 @@ func WithHTTPClient(c *http.Client) ClientOption {
 	}
 }
 
+// WithChannelSecret function
+// It sets the channel secret used to validate webhook requests, instead of
+// the argument of New.
+func WithChannelSecret(channelSecret string) ClientOption {
+	return func(client *Client) error {
+		client.channelSecret = channelSecret
+		return nil
+	}
+}
+
+// WithChannelID function
+func WithChannelID(channelID string) ClientOption {
+	return func(client *Client) error {
+		client.channelID = channelID
+		return nil
+	}
+}
+
 // WithEndpointBase function
 func WithEndpointBase(endpointBase string) ClientOption {
 	return func(client *Client) error {
@@ -97,35 +166,108 @@ func WithEndpointBase(endpointBase string) ClientOption {
 	}
 }
 
+// DefaultTransport function
+// It returns a transport which applies `connect` to establishing connections
+// including the TLS handshake, and `read` to waiting for response headers.
+// Use it with WithHTTPClient to configure the timeouts separately.
+func DefaultTransport(connect, read time.Duration) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: (&net.Dialer{
+			Timeout:   connect,
+			KeepAlive: 30 * time.Second,
+		}).Dial,
+		TLSHandshakeTimeout:   connect,
+		ResponseHeaderTimeout: read,
+	}
+}
+
 func (client *Client) url(endpoint string) string {
 	u := *client.endpointBase
 	u.Path = path.Join(u.Path, endpoint)
 	return u.String()
 }
 
-func (client *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+func (client *Client) setHeaders(req *http.Request) {
 	req.Header.Set("Authorization", "Bearer "+client.channelToken)
 	req.Header.Set("User-Agent", "LINE-BotSDK-Go/"+version)
+	if client.channelID != "" {
+		req.Header.Set("X-Line-ChannelId", client.channelID)
+	}
+}
+
+// do sends `req`. The encoded `body` of the request, if any, is given for logging.
+func (client *Client) do(ctx context.Context, req *http.Request, body []byte) (res *http.Response, err error) {
+	client.setHeaders(req)
+	if client.metrics != nil {
+		defer func(start time.Time) {
+			statusCode := 0
+			if res != nil {
+				statusCode = res.StatusCode
+			}
+			client.metrics(client.operation(req), statusCode, time.Since(start))
+		}(time.Now())
+	}
+	if client.logger != nil {
+		defer func() {
+			client.logRequest(req, body, res, err)
+		}()
+	}
 	if ctx != nil {
 		return ctxhttp.Do(ctx, client.httpClient, req)
 	}
 	return client.httpClient.Do(req)
-
 }
 
-func (client *Client) get(ctx context.Context, endpoint string) (*http.Response, error) {
+func (client *Client) get(ctx context.Context, endpoint string, query url.Values) (*http.Response, error) {
 	req, err := http.NewRequest("GET", client.url(endpoint), nil)
 	if err != nil {
 		return nil, err
 	}
-	return client.do(ctx, req)
+	if query != nil {
+		req.URL.RawQuery = query.Encode()
+	}
+	return client.do(ctx, req, nil)
+}
+
+func (client *Client) post(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	req, err := client.newPostRequest(endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return client.do(ctx, req, body)
+}
+
+func (client *Client) newPostRequest(endpoint string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest("POST", client.url(endpoint), bodyReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return req, nil
 }
 
-func (client *Client) post(ctx context.Context, endpoint string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest("POST", client.url(endpoint), body)
+func (client *Client) put(ctx context.Context, endpoint string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest("PUT", client.url(endpoint), bodyReader(body))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	return client.do(ctx, req)
+	return client.do(ctx, req, body)
+}
+
+func (client *Client) delete(ctx context.Context, endpoint string) (*http.Response, error) {
+	req, err := http.NewRequest("DELETE", client.url(endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.do(ctx, req, nil)
+}
+
+// bodyReader returns a reader of `body`, or nil if there is no body.
+func bodyReader(body []byte) io.Reader {
+	if body == nil {
+		return nil
+	}
+	return bytes.NewReader(body)
 }
@@ -21,6 +21,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func mockClient(server *httptest.Server) (*Client, error) {
@@ -86,3 +87,61 @@ func TestNewClientWithOptions(t *testing.T) {
 		t.Errorf("httpClient %p; want %p", client.httpClient, &httpClient)
 	}
 }
+
+func TestDefaultTransport(t *testing.T) {
+	transport := DefaultTransport(time.Second, 5*time.Millisecond)
+	if transport.TLSHandshakeTimeout != time.Second {
+		t.Errorf("TLSHandshakeTimeout %v; want %v", transport.TLSHandshakeTimeout, time.Second)
+	}
+	if transport.ResponseHeaderTimeout != 5*time.Millisecond {
+		t.Errorf("ResponseHeaderTimeout %v; want %v", transport.ResponseHeaderTimeout, 5*time.Millisecond)
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	client, err := New("testsecret", "testtoken", WithHTTPClient(&http.Client{Transport: transport}), WithEndpointBase(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetProfile("U0047556f2e40dba2456887320ba7c76d").Do(); err == nil {
+		t.Error("err nil; want read timeout")
+	}
+}
+
+func TestWithChannelID(t *testing.T) {
+	var got []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		got = append(got, r.Header.Get("X-Line-ChannelId"))
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	withoutID, err := New("testsecret", "testtoken", WithHTTPClient(httpClient), WithEndpointBase(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withID, err := New("testsecret", "testtoken", WithHTTPClient(httpClient), WithEndpointBase(server.URL), WithChannelID("1234567890"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := withoutID.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).Do(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := withID.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).Do(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"", "1234567890"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("X-Line-ChannelId %q; want %q", got, want)
+	}
+}
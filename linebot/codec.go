@@ -0,0 +1,75 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// JSONCodec interface
+// It encodes the request bodies and decodes the responses of API calls,
+// so that a faster implementation than encoding/json can be used.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// stdJSONCodec is the default JSONCodec using encoding/json
+type stdJSONCodec struct{}
+
+func (stdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// WithJSONCodec function
+func WithJSONCodec(codec JSONCodec) ClientOption {
+	return func(client *Client) error {
+		if codec == nil {
+			return errors.New("nil JSON codec")
+		}
+		client.codec = codec
+		return nil
+	}
+}
+
+// encodeJSON writes `v` followed by a newline in the same way as json.Encoder.
+func (client *Client) encodeJSON(w io.Writer, v interface{}) error {
+	b, err := client.codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// decodeJSON decodes the body of a successful response into `v`.
+func decodeJSON(res *http.Response, codec JSONCodec, v interface{}) error {
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if err := codec.Unmarshal(b, v); err != nil {
+		return decodeError(res, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// recordingCodec is a JSONCodec recording the types it encodes and decodes
+type recordingCodec struct {
+	marshaled   []string
+	unmarshaled []string
+}
+
+func (c *recordingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshaled = append(c.marshaled, reflect.TypeOf(v).String())
+	return json.Marshal(v)
+}
+
+func (c *recordingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshaled = append(c.unmarshaled, reflect.TypeOf(v).String())
+	return json.Unmarshal(data, v)
+}
+
+func TestWithJSONCodec(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		switch r.URL.Path {
+		case APIEndpointPushMessage:
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{"userId":"U0047556f2e40dba2456887320ba7c76d","displayName":"BOT API","pictureUrl":"https://obs.line-apps.com/abcdefghijklmn","statusMessage":"Hello, LINE!"}`))
+		}
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := &recordingCodec{}
+	if err := WithJSONCodec(codec)(client); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).Do(); err != nil {
+		t.Fatal(err)
+	}
+	profile, err := client.GetProfile("U0047556f2e40dba2456887320ba7c76d").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.DisplayName != "BOT API" {
+		t.Errorf("DisplayName %s; want BOT API", profile.DisplayName)
+	}
+	if len(codec.marshaled) != 1 {
+		t.Errorf("marshaled %v; want the push request", codec.marshaled)
+	}
+	want := []string{"*linebot.BasicResponse", "*linebot.UserProfileResponse"}
+	if !reflect.DeepEqual(codec.unmarshaled, want) {
+		t.Errorf("unmarshaled %v; want %v", codec.unmarshaled, want)
+	}
+}
+
+func TestWithJSONCodecNil(t *testing.T) {
+	if _, err := New("testsecret", "testtoken", WithJSONCodec(nil)); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"errors"
+
+	"golang.org/x/net/context"
+)
+
+// WithConcurrency function
+// Once set, at most `n` requests are sent at once by GetProfiles and
+// MulticastAll of the client in total, in addition to their own limits.
+func WithConcurrency(n int) ClientOption {
+	return func(client *Client) error {
+		if n <= 0 {
+			return errors.New("concurrency must be positive")
+		}
+		client.semaphore = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// acquire waits for a slot of WithConcurrency unless `ctx` is done first.
+func (client *Client) acquire(ctx context.Context) error {
+	if client.semaphore == nil {
+		return nil
+	}
+	if ctx == nil {
+		client.semaphore <- struct{}{}
+		return nil
+	}
+	select {
+	case client.semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (client *Client) release() {
+	if client.semaphore != nil {
+		<-client.semaphore
+	}
+}
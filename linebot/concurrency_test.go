@@ -0,0 +1,84 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight, requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		mu.Lock()
+		requests++
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WithConcurrency(3)(client); err != nil {
+		t.Fatal(err)
+	}
+	var to []string
+	for i := 0; i < 320; i++ {
+		to = append(to, fmt.Sprintf("U%03d", i))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, errs := client.GetProfiles(to[:5]).Do(); len(errs) > 0 {
+				t.Error(errs)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := client.MulticastAll(to, []Message{NewTextMessage("Hello, world")}).Do(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if requests != 4*5+4*3 {
+		t.Errorf("requests %d; want %d", requests, 4*5+4*3)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("requests in flight %d; want at most 3", maxInFlight)
+	}
+
+	if _, err := New("testsecret", "testtoken", WithConcurrency(0)); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+)
+
+// EchoHandler function
+// It returns a webhook handler which replies to each text message with the
+// same text, as a reference implementation and a smoke test of a channel.
+// Other events are ignored. 500 is returned if a reply fails.
+func EchoHandler(client *Client) http.Handler {
+	return client.WebhookHandler(func(events []*Event, w http.ResponseWriter, r *http.Request) {
+		failed := false
+		for _, event := range events {
+			if event.Type != EventTypeMessage {
+				continue
+			}
+			message, ok := event.Message.(*TextMessage)
+			if !ok {
+				continue
+			}
+			if _, err := client.ReplyMessage(event.ReplyToken, NewTextMessage(message.Text)).Do(); err != nil {
+				if client.logger != nil {
+					client.logger.Printf("linebot: failed to echo message %s: %v", message.ID, err)
+				}
+				failed = true
+			}
+		}
+		if failed {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
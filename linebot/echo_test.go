@@ -0,0 +1,93 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEchoHandler(t *testing.T) {
+	var replies []string
+	api := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.URL.Path != APIEndpointReplyMessage {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointReplyMessage)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		replies = append(replies, string(body))
+		w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+	client, err := mockClient(api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(EchoHandler(client))
+	defer server.Close()
+
+	body := []byte(`{
+    "events": [
+        {
+            "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+            "type": "message",
+            "timestamp": 1462629479859,
+            "source": {"type": "user", "userId": "U206d25c2ea6bd87c17655609a1c37cb8"},
+            "message": {"id": "325708", "type": "text", "text": "Hello, world"}
+        },
+        {
+            "replyToken": "b60d432864f44d079f6d8efe86cf404b",
+            "type": "message",
+            "timestamp": 1462629479860,
+            "source": {"type": "user", "userId": "U206d25c2ea6bd87c17655609a1c37cb8"},
+            "message": {"id": "325709", "type": "sticker", "packageId": "1", "stickerId": "1"}
+        },
+        {
+            "replyToken": "8cf9239d56244f4197887e939187e19e",
+            "type": "follow",
+            "timestamp": 1462629479861,
+            "source": {"type": "user", "userId": "U206d25c2ea6bd87c17655609a1c37cb8"}
+        }
+    ]
+}`)
+	req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, []byte("testsecret"))
+	mac.Write(body)
+	req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode %d; want %d", res.StatusCode, http.StatusOK)
+	}
+	want := `{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"text","text":"Hello, world"}]}` + "\n"
+	if len(replies) != 1 || replies[0] != want {
+		t.Errorf("replies %q; want %q", replies, want)
+	}
+}
@@ -18,28 +18,134 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"net/http"
 )
 
 // errors
 var (
-	ErrInvalidSignature = errors.New("invalid signature")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrInvalidContentType = errors.New("invalid content type")
+	ErrContentTooLarge    = errors.New("content too large")
+	ErrTooManyMessages    = errors.New("too many messages")
+	ErrTextTooLong        = errors.New("text too long")
+	ErrRangeIgnored       = errors.New("range request ignored")
+	ErrNoEventSource      = errors.New("event has no source")
+
+	ErrInconsistentNotificationDisabled = errors.New("notificationDisabled is set to different values in a request")
+	ErrEventQueueClosed                 = errors.New("event queue is closed")
 )
 
 // APIError type
+// `RequestID` is the value of the X-Line-Request-Id response header, which is
+// needed when asking LINE about the error.
 type APIError struct {
-	Code     int
-	Response *ErrorResponse
+	Code      int
+	Response  *ErrorResponse
+	RequestID string
 }
 
 // Error method
 func (e *APIError) Error() string {
 	var buf bytes.Buffer
-	fmt.Fprintf(&buf, "linebot: APIError %d ", e.Code)
+	fmt.Fprintf(&buf, "linebot: APIError %d", e.Code)
+	if e.Response != nil {
+		fmt.Fprintf(&buf, " %s", e.Response.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&buf, " (requestId=%s)", e.RequestID)
+	}
 	if e.Response != nil {
-		fmt.Fprintf(&buf, "%s", e.Response.Message)
 		for _, d := range e.Response.Details {
 			fmt.Fprintf(&buf, "\n[%s] %s", d.Property, d.Message)
 		}
 	}
 	return buf.String()
 }
+
+// IsBadRequest method
+func (e *APIError) IsBadRequest() bool {
+	return e.Code == http.StatusBadRequest
+}
+
+// IsNotFound method
+func (e *APIError) IsNotFound() bool {
+	return e.Code == http.StatusNotFound
+}
+
+// IsRateLimited method
+func (e *APIError) IsRateLimited() bool {
+	return e.Code == http.StatusTooManyRequests
+}
+
+// IsServerError method
+func (e *APIError) IsServerError() bool {
+	return e.Code >= http.StatusInternalServerError && e.Code < 600
+}
+
+// DecodeError type
+// It is returned when a successful response cannot be decoded, which usually
+// means that the request was sent to a wrong endpoint base or through a proxy.
+type DecodeError struct {
+	Endpoint string
+	Err      error
+}
+
+// Error method
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("linebot: failed to decode the response from %s: %v (check the endpoint base and proxies)", e.Endpoint, e.Err)
+}
+
+// ValidationError type
+// It is returned by local validations before a request is sent.
+// `Field` names the invalid property in the same way as `Property` in the error response details.
+// `Err` is the sentinel error of the failure if there is one, such as ErrTextTooLong.
+type ValidationError struct {
+	Field  string
+	Reason string
+	Err    error
+}
+
+// Error method
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("linebot: invalid %s: %s", e.Field, e.Reason)
+}
+
+// Unwrap method
+// It returns `Err` so that errors.Is(err, ErrTextTooLong) reports true.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// prefixValidationError prefixes the field of `err` with `prefix` if it is a
+// *ValidationError.
+func prefixValidationError(prefix string, err error) error {
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		return err
+	}
+	return &ValidationError{
+		Field:  prefix + "." + verr.Field,
+		Reason: verr.Reason,
+		Err:    verr.Err,
+	}
+}
+
+// MulticastChunkError type
+// It is a failed multicast to `To`, which is a part of the recipients of
+// MulticastAll.
+type MulticastChunkError struct {
+	To  []string
+	Err error
+}
+
+// MulticastError type
+// It is returned by MulticastAll when some of the `Chunks` multicasts failed.
+type MulticastError struct {
+	Chunks   int
+	Failures []*MulticastChunkError
+}
+
+// Error method
+func (e *MulticastError) Error() string {
+	return fmt.Sprintf("linebot: %d of %d multicasts failed: %v", len(e.Failures), e.Chunks, e.Failures[0].Err)
+}
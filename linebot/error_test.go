@@ -0,0 +1,154 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIErrorHelpers(t *testing.T) {
+	type want struct {
+		BadRequest  bool
+		NotFound    bool
+		RateLimited bool
+		ServerError bool
+	}
+	var testCases = []struct {
+		Code int
+		Want want
+	}{
+		{Code: 400, Want: want{BadRequest: true}},
+		{Code: 401, Want: want{}},
+		{Code: 404, Want: want{NotFound: true}},
+		{Code: 429, Want: want{RateLimited: true}},
+		{Code: 500, Want: want{ServerError: true}},
+		{Code: 503, Want: want{ServerError: true}},
+	}
+	for _, tc := range testCases {
+		err := &APIError{Code: tc.Code}
+		got := want{
+			BadRequest:  err.IsBadRequest(),
+			NotFound:    err.IsNotFound(),
+			RateLimited: err.IsRateLimited(),
+			ServerError: err.IsServerError(),
+		}
+		if got != tc.Want {
+			t.Errorf("APIError %d %+v; want %+v", tc.Code, got, tc.Want)
+		}
+	}
+}
+
+func TestAPIErrorError(t *testing.T) {
+	var testCases = []struct {
+		Error *APIError
+		Want  string
+	}{
+		{
+			Error: &APIError{
+				Code: 400,
+				Response: &ErrorResponse{
+					Message: "The request body has 2 error(s)",
+					Details: []ErrorDetail{
+						{Message: "May not be empty", Property: "messages[0].text"},
+						{Message: "Must be one of the following values: [text, image, video, audio, location, sticker, template, imagemap]", Property: "messages[1].type"},
+					},
+				},
+				RequestID: "3a785346-2cf3-482f-8cfc-3b8fd1a7b8e8",
+			},
+			Want: "linebot: APIError 400 The request body has 2 error(s) (requestId=3a785346-2cf3-482f-8cfc-3b8fd1a7b8e8)\n" +
+				"[messages[0].text] May not be empty\n" +
+				"[messages[1].type] Must be one of the following values: [text, image, video, audio, location, sticker, template, imagemap]",
+		},
+		{
+			Error: &APIError{
+				Code:     404,
+				Response: &ErrorResponse{Message: "Not found"},
+			},
+			Want: "linebot: APIError 404 Not found",
+		},
+		{
+			Error: &APIError{Code: 500},
+			Want:  "linebot: APIError 500",
+		},
+	}
+	for i, tc := range testCases {
+		if got := tc.Error.Error(); got != tc.Want {
+			t.Errorf("Error %d %q; want %q", i, got, tc.Want)
+		}
+	}
+}
+
+func TestMulticastErrorError(t *testing.T) {
+	err := &MulticastError{
+		Chunks: 3,
+		Failures: []*MulticastChunkError{
+			{To: []string{"Ublocked"}, Err: &APIError{Code: 400}},
+		},
+	}
+	if want := "linebot: 1 of 3 multicasts failed: linebot: APIError 400"; err.Error() != want {
+		t.Errorf("Error %q; want %q", err.Error(), want)
+	}
+}
+
+func TestAPIErrorRequestID(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Header().Set("X-Line-Request-Id", "3a785346-2cf3-482f-8cfc-3b8fd1a7b8e8")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"Invalid reply token"}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.ReplyMessage("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", NewTextMessage("Hello, world")).Do()
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("err %v; want *APIError", err)
+	}
+	if apiErr.RequestID != "3a785346-2cf3-482f-8cfc-3b8fd1a7b8e8" {
+		t.Errorf("RequestID %s; want %s", apiErr.RequestID, "3a785346-2cf3-482f-8cfc-3b8fd1a7b8e8")
+	}
+}
+
+func TestErrorResponseDetails(t *testing.T) {
+	res := &ErrorResponse{}
+	body := `{"message":"The request body has 2 error(s)","details":[{"message":"May not be empty","property":"messages[0].text"},{"message":"Length must be between 0 and 2000","property":"messages[1].text"}]}`
+	if err := json.Unmarshal([]byte(body), res); err != nil {
+		t.Fatal(err)
+	}
+	want := []ErrorDetail{
+		{Message: "May not be empty", Property: "messages[0].text"},
+		{Message: "Length must be between 0 and 2000", Property: "messages[1].text"},
+	}
+	if len(res.Details) != len(want) {
+		t.Fatalf("Details %d; want %d", len(res.Details), len(want))
+	}
+	for i, d := range res.Details {
+		if d != want[i] {
+			t.Errorf("Detail %d %+v; want %+v", i, d, want[i])
+		}
+		if got := res.DetailFor(want[i].Property); got == nil || *got != want[i] {
+			t.Errorf("DetailFor %s %+v; want %+v", want[i].Property, got, want[i])
+		}
+	}
+	if got := res.DetailFor("messages[2].text"); got != nil {
+		t.Errorf("DetailFor %s %+v; want nil", "messages[2].text", got)
+	}
+}
@@ -15,6 +15,7 @@
 package linebot
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"time"
 )
@@ -24,13 +25,26 @@ type EventType string
 
 // EventType constants
 const (
-	EventTypeMessage  EventType = "message"
-	EventTypeFollow   EventType = "follow"
-	EventTypeUnfollow EventType = "unfollow"
-	EventTypeJoin     EventType = "join"
-	EventTypeLeave    EventType = "leave"
-	EventTypePostback EventType = "postback"
-	EventTypeBeacon   EventType = "beacon"
+	EventTypeMessage      EventType = "message"
+	EventTypeFollow       EventType = "follow"
+	EventTypeUnfollow     EventType = "unfollow"
+	EventTypeJoin         EventType = "join"
+	EventTypeLeave        EventType = "leave"
+	EventTypePostback     EventType = "postback"
+	EventTypeBeacon       EventType = "beacon"
+	EventTypeDelivery     EventType = "delivery"
+	EventTypeAccountLink  EventType = "accountLink"
+	EventTypeMemberJoined EventType = "memberJoined"
+	EventTypeMemberLeft   EventType = "memberLeft"
+)
+
+// EventMode type
+type EventMode string
+
+// EventMode constants
+const (
+	EventModeActive  EventMode = "active"
+	EventModeStandby EventMode = "standby"
 )
 
 // EventSourceType type
@@ -51,6 +65,28 @@ type EventSource struct {
 	RoomID  string          `json:"roomId,omitempty"`
 }
 
+// ID method
+// It returns GroupID, RoomID or UserID for a group, room or user source respectively.
+func (s *EventSource) ID() string {
+	switch s.Type {
+	case EventSourceTypeGroup:
+		return s.GroupID
+	case EventSourceTypeRoom:
+		return s.RoomID
+	}
+	return s.UserID
+}
+
+// ChatIDFromSource function
+// It returns the chatId used by the loading animation and mark-as-read APIs,
+// which is the ID of the user, group or room the event came from.
+func ChatIDFromSource(source *EventSource) string {
+	if source == nil {
+		return ""
+	}
+	return source.ID()
+}
+
 // Postback type
 type Postback struct {
 	Data string `json:"data"`
@@ -61,34 +97,89 @@ type BeaconEventType string
 
 // BeaconEventType constants
 const (
-	BeaconEventTypeEnter BeaconEventType = "enter"
+	BeaconEventTypeEnter  BeaconEventType = "enter"
+	BeaconEventTypeLeave  BeaconEventType = "leave"
+	BeaconEventTypeBanner BeaconEventType = "banner"
 )
 
 // Beacon type
 type Beacon struct {
-	Hwid string          `json:"hwid"`
-	Type BeaconEventType `json:"type"`
+	Hwid          string          `json:"hwid"`
+	Type          BeaconEventType `json:"type"`
+	DeviceMessage []byte          `json:"-"`
+}
+
+// AccountLinkResult type
+type AccountLinkResult string
+
+// AccountLinkResult constants
+const (
+	AccountLinkResultOK     AccountLinkResult = "ok"
+	AccountLinkResultFailed AccountLinkResult = "failed"
+)
+
+// Link type
+type Link struct {
+	Result AccountLinkResult `json:"result"`
+	Nonce  string            `json:"nonce"`
+}
+
+// Delivery type
+type Delivery struct {
+	Data string `json:"data"`
+}
+
+// DeliveryContext type
+// `IsRedelivery` is true when the event is sent again because an earlier
+// webhook request for it failed.
+type DeliveryContext struct {
+	IsRedelivery bool `json:"isRedelivery"`
 }
 
 // Event type
 type Event struct {
 	ReplyToken string
 	Type       EventType
+	Mode       EventMode
 	Timestamp  time.Time
 	Source     *EventSource
 	Message    Message
 	Postback   *Postback
 	Beacon     *Beacon
+	Delivery   *Delivery
+	Link       *Link
+	Members    []*EventSource
+
+	WebhookEventID  string
+	DeliveryContext *DeliveryContext
 }
 
 type rawEvent struct {
 	ReplyToken string           `json:"replyToken,omitempty"`
 	Type       EventType        `json:"type"`
+	Mode       EventMode        `json:"mode,omitempty"`
 	Timestamp  int64            `json:"timestamp"`
 	Source     *EventSource     `json:"source"`
 	Message    *rawEventMessage `json:"message,omitempty"`
-	*Postback  `json:"postback,omitempty"`
-	*Beacon    `json:"beacon,omitempty"`
+	Postback   *Postback        `json:"postback,omitempty"`
+	Beacon     *rawBeacon       `json:"beacon,omitempty"`
+	Delivery   *Delivery        `json:"delivery,omitempty"`
+	Link       *Link            `json:"link,omitempty"`
+	Joined     *rawMembers      `json:"joined,omitempty"`
+	Left       *rawMembers      `json:"left,omitempty"`
+
+	WebhookEventID  string           `json:"webhookEventId,omitempty"`
+	DeliveryContext *DeliveryContext `json:"deliveryContext,omitempty"`
+}
+
+type rawMembers struct {
+	Members []*EventSource `json:"members"`
+}
+
+type rawBeacon struct {
+	Hwid string          `json:"hwid"`
+	Type BeaconEventType `json:"type"`
+	DM   string          `json:"dm,omitempty"`
 }
 
 type rawEventMessage struct {
@@ -96,12 +187,17 @@ type rawEventMessage struct {
 	Type      MessageType `json:"type"`
 	Text      string      `json:"text,omitempty"`
 	Duration  int         `json:"duration,omitempty"`
+	FileName  string      `json:"fileName,omitempty"`
+	FileSize  int         `json:"fileSize,omitempty"`
 	Title     string      `json:"title,omitempty"`
 	Address   string      `json:"address,omitempty"`
 	Latitude  float64     `json:"latitude,omitempty"`
 	Longitude float64     `json:"longitude,omitempty"`
 	PackageID string      `json:"packageId,omitempty"`
 	StickerID string      `json:"stickerId,omitempty"`
+
+	StickerResourceType StickerResourceType `json:"stickerResourceType,omitempty"`
+	Keywords            []string            `json:"keywords,omitempty"`
 }
 
 const (
@@ -114,10 +210,29 @@ func (e *Event) MarshalJSON() ([]byte, error) {
 	raw := rawEvent{
 		ReplyToken: e.ReplyToken,
 		Type:       e.Type,
+		Mode:       e.Mode,
 		Timestamp:  e.Timestamp.Unix()*millisecPerSec + int64(e.Timestamp.Nanosecond())/int64(time.Millisecond),
 		Source:     e.Source,
 		Postback:   e.Postback,
-		Beacon:     e.Beacon,
+		Delivery:   e.Delivery,
+		Link:       e.Link,
+
+		WebhookEventID:  e.WebhookEventID,
+		DeliveryContext: e.DeliveryContext,
+	}
+	if e.Beacon != nil {
+		raw.Beacon = &rawBeacon{
+			Hwid: e.Beacon.Hwid,
+			Type: e.Beacon.Type,
+			DM:   hex.EncodeToString(e.Beacon.DeviceMessage),
+		}
+	}
+
+	switch e.Type {
+	case EventTypeMemberJoined:
+		raw.Joined = &rawMembers{Members: e.Members}
+	case EventTypeMemberLeft:
+		raw.Left = &rawMembers{Members: e.Members}
 	}
 
 	switch m := e.Message.(type) {
@@ -134,8 +249,9 @@ func (e *Event) MarshalJSON() ([]byte, error) {
 		}
 	case *VideoMessage:
 		raw.Message = &rawEventMessage{
-			Type: MessageTypeVideo,
-			ID:   m.ID,
+			Type:     MessageTypeVideo,
+			ID:       m.ID,
+			Duration: m.Duration,
 		}
 	case *AudioMessage:
 		raw.Message = &rawEventMessage{
@@ -143,6 +259,13 @@ func (e *Event) MarshalJSON() ([]byte, error) {
 			ID:       m.ID,
 			Duration: m.Duration,
 		}
+	case *FileMessage:
+		raw.Message = &rawEventMessage{
+			Type:     MessageTypeFile,
+			ID:       m.ID,
+			FileName: m.FileName,
+			FileSize: m.FileSize,
+		}
 	case *LocationMessage:
 		raw.Message = &rawEventMessage{
 			Type:      MessageTypeLocation,
@@ -158,6 +281,9 @@ func (e *Event) MarshalJSON() ([]byte, error) {
 			ID:        m.ID,
 			PackageID: m.PackageID,
 			StickerID: m.StickerID,
+
+			StickerResourceType: m.StickerResourceType,
+			Keywords:            m.Keywords,
 		}
 	}
 	return json.Marshal(&raw)
@@ -172,8 +298,11 @@ func (e *Event) UnmarshalJSON(body []byte) (err error) {
 
 	e.ReplyToken = rawEvent.ReplyToken
 	e.Type = rawEvent.Type
+	e.Mode = rawEvent.Mode
 	e.Timestamp = time.Unix(rawEvent.Timestamp/millisecPerSec, (rawEvent.Timestamp%millisecPerSec)*nanosecPerMillisec).UTC()
 	e.Source = rawEvent.Source
+	e.WebhookEventID = rawEvent.WebhookEventID
+	e.DeliveryContext = rawEvent.DeliveryContext
 
 	switch rawEvent.Type {
 	case EventTypeMessage:
@@ -189,13 +318,20 @@ func (e *Event) UnmarshalJSON(body []byte) (err error) {
 			}
 		case MessageTypeVideo:
 			e.Message = &VideoMessage{
-				ID: rawEvent.Message.ID,
+				ID:       rawEvent.Message.ID,
+				Duration: rawEvent.Message.Duration,
 			}
 		case MessageTypeAudio:
 			e.Message = &AudioMessage{
 				ID:       rawEvent.Message.ID,
 				Duration: rawEvent.Message.Duration,
 			}
+		case MessageTypeFile:
+			e.Message = &FileMessage{
+				ID:       rawEvent.Message.ID,
+				FileName: rawEvent.Message.FileName,
+				FileSize: rawEvent.Message.FileSize,
+			}
 		case MessageTypeLocation:
 			e.Message = &LocationMessage{
 				ID:        rawEvent.Message.ID,
@@ -209,12 +345,38 @@ func (e *Event) UnmarshalJSON(body []byte) (err error) {
 				ID:        rawEvent.Message.ID,
 				PackageID: rawEvent.Message.PackageID,
 				StickerID: rawEvent.Message.StickerID,
+
+				StickerResourceType: rawEvent.Message.StickerResourceType,
+				Keywords:            rawEvent.Message.Keywords,
 			}
 		}
 	case EventTypePostback:
 		e.Postback = rawEvent.Postback
+	case EventTypeDelivery:
+		e.Delivery = rawEvent.Delivery
+	case EventTypeAccountLink:
+		e.Link = rawEvent.Link
+	case EventTypeMemberJoined:
+		if rawEvent.Joined != nil {
+			e.Members = rawEvent.Joined.Members
+		}
+	case EventTypeMemberLeft:
+		if rawEvent.Left != nil {
+			e.Members = rawEvent.Left.Members
+		}
 	case EventTypeBeacon:
-		e.Beacon = rawEvent.Beacon
+		if rawEvent.Beacon == nil {
+			break
+		}
+		e.Beacon = &Beacon{
+			Hwid: rawEvent.Beacon.Hwid,
+			Type: rawEvent.Beacon.Type,
+		}
+		if rawEvent.Beacon.DM != "" {
+			if e.Beacon.DeviceMessage, err = hex.DecodeString(rawEvent.Beacon.DM); err != nil {
+				return
+			}
+		}
 	}
 	return
 }
@@ -0,0 +1,82 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"sync"
+	"time"
+)
+
+// EventDeduplicator type
+// It detects events which are delivered again, such as those in a webhook
+// redelivered after a failed response, by their webhook event IDs.
+type EventDeduplicator struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	seen  map[string]time.Time
+	swept time.Time
+	now   func() time.Time
+}
+
+// NewEventDeduplicator function
+// Webhook event IDs are remembered for `ttl`, which should be longer than
+// the period in which LINE redelivers webhooks.
+func NewEventDeduplicator(ttl time.Duration) *EventDeduplicator {
+	return &EventDeduplicator{
+		ttl:  ttl,
+		seen: map[string]time.Time{},
+		now:  time.Now,
+	}
+}
+
+// Filter method
+// It returns the events which haven't been seen and remembers them.
+// Events without a webhook event ID are always returned.
+func (d *EventDeduplicator) Filter(events []*Event) []*Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	// expired IDs are swept once per TTL rather than on every call
+	if now.Sub(d.swept) > d.ttl {
+		for id, seenAt := range d.seen {
+			if now.Sub(seenAt) > d.ttl {
+				delete(d.seen, id)
+			}
+		}
+		d.swept = now
+	}
+	result := make([]*Event, 0, len(events))
+	for _, event := range events {
+		if event.WebhookEventID != "" {
+			if seenAt, ok := d.seen[event.WebhookEventID]; ok && now.Sub(seenAt) <= d.ttl {
+				continue
+			}
+			d.seen[event.WebhookEventID] = now
+		}
+		result = append(result, event)
+	}
+	return result
+}
+
+// Forget method
+// It forgets the events returned by Filter which couldn't be handled, so that
+// they aren't filtered out when they are delivered again.
+func (d *EventDeduplicator) Forget(events []*Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, event := range events {
+		delete(d.seen, event.WebhookEventID)
+	}
+}
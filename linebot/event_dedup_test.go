@@ -0,0 +1,61 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventDeduplicator(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewEventDeduplicator(time.Hour)
+	d.now = func() time.Time { return now }
+
+	first := &Event{WebhookEventID: "01FZ74A0TDDPYRVKNK77XKC3ZR"}
+	second := &Event{WebhookEventID: "01FZ74ASS536FW97EX38NKCZQK"}
+	noID := &Event{}
+	var testCases = []struct {
+		After  time.Duration
+		Events []*Event
+		Want   []*Event
+	}{
+		{Events: []*Event{first, noID}, Want: []*Event{first, noID}},
+		// Redelivered with a new event
+		{After: time.Minute, Events: []*Event{first, second, noID}, Want: []*Event{second, noID}},
+		{After: time.Minute, Events: []*Event{first, second}, Want: []*Event{}},
+		// Forgotten after the TTL
+		{After: 2 * time.Hour, Events: []*Event{first}, Want: []*Event{first}},
+	}
+	for i, tc := range testCases {
+		now = now.Add(tc.After)
+		if got := d.Filter(tc.Events); !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("Filter %d %v; want %v", i, got, tc.Want)
+		}
+	}
+}
+
+func TestEventDeduplicatorForget(t *testing.T) {
+	d := NewEventDeduplicator(time.Hour)
+	first := &Event{WebhookEventID: "01FZ74A0TDDPYRVKNK77XKC3ZR"}
+	second := &Event{WebhookEventID: "01FZ74ASS536FW97EX38NKCZQK"}
+	d.Filter([]*Event{first, second})
+	d.Forget([]*Event{second})
+	want := []*Event{second}
+	if got := d.Filter([]*Event{first, second}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"sort"
+	"sync"
+)
+
+// EventQueue type
+// It delivers webhook events to consumers in order of their timestamp, and
+// then their webhook event ID, per source. The events from a source are
+// delivered one at a time; the next one is delivered after Done is called for
+// the previous one, so that they are processed in the order they happened even
+// if they arrive out of order, while the other sources are not blocked.
+type EventQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	sources map[string][]*Event // pending events by source
+	busy    map[string]bool     // sources ready or being processed
+	ready   []string
+	pending int
+	closed  bool
+	events  chan *Event
+}
+
+// NewEventQueue function
+// `size` is the buffer size of the channel returned by Events.
+func NewEventQueue(size int) *EventQueue {
+	q := &EventQueue{
+		sources: map[string][]*Event{},
+		busy:    map[string]bool{},
+		events:  make(chan *Event, size),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	go q.dispatch()
+	return q
+}
+
+// Push method
+// It queues the events without blocking. Events still waiting in the queue
+// are reordered with those pushed later. ErrEventQueueClosed is returned
+// after Close.
+func (q *EventQueue) Push(events []*Event) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrEventQueueClosed
+	}
+	for _, event := range events {
+		key := eventQueueKey(event)
+		pending := append(q.sources[key], event)
+		sort.Stable(eventsByOrder(pending))
+		q.sources[key] = pending
+		q.pending++
+		if !q.busy[key] {
+			q.busy[key] = true
+			q.ready = append(q.ready, key)
+		}
+	}
+	q.cond.Signal()
+	return nil
+}
+
+// Events method
+func (q *EventQueue) Events() <-chan *Event {
+	return q.events
+}
+
+// Done method
+// It must be called when an event received from Events has been processed.
+func (q *EventQueue) Done(event *Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	key := eventQueueKey(event)
+	if !q.busy[key] {
+		return
+	}
+	if len(q.sources[key]) > 0 {
+		q.ready = append(q.ready, key)
+		q.cond.Signal()
+		return
+	}
+	delete(q.busy, key)
+	delete(q.sources, key)
+}
+
+// Close method
+// The channel returned by Events is closed once the pushed events are delivered.
+func (q *EventQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Signal()
+}
+
+func (q *EventQueue) dispatch() {
+	for {
+		q.mu.Lock()
+		for len(q.ready) == 0 && !(q.closed && q.pending == 0) {
+			q.cond.Wait()
+		}
+		if len(q.ready) == 0 {
+			q.mu.Unlock()
+			close(q.events)
+			return
+		}
+		key := q.ready[0]
+		q.ready = q.ready[1:]
+		event := q.sources[key][0]
+		q.sources[key] = q.sources[key][1:]
+		q.pending--
+		q.mu.Unlock()
+		q.events <- event
+	}
+}
+
+func eventQueueKey(event *Event) string {
+	if event.Source == nil {
+		return ""
+	}
+	return string(event.Source.Type) + ":" + event.Source.ID()
+}
+
+type eventsByOrder []*Event
+
+func (e eventsByOrder) Len() int      { return len(e) }
+func (e eventsByOrder) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+func (e eventsByOrder) Less(i, j int) bool {
+	if !e[i].Timestamp.Equal(e[j].Timestamp) {
+		return e[i].Timestamp.Before(e[j].Timestamp)
+	}
+	return e[i].WebhookEventID < e[j].WebhookEventID
+}
@@ -0,0 +1,111 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newQueueTestEvent(id string, offset time.Duration, userID string) *Event {
+	return &Event{
+		Type:           EventTypeMessage,
+		Timestamp:      time.Date(2016, time.May, 7, 13, 57, 59, 0, time.UTC).Add(offset),
+		Source:         &EventSource{Type: EventSourceTypeUser, UserID: userID},
+		WebhookEventID: id,
+	}
+}
+
+func TestEventQueue(t *testing.T) {
+	queue := NewEventQueue(10)
+	if err := queue.Push([]*Event{
+		newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZR", 2*time.Second, "U1"),
+		newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZP", 0, "U1"),
+		newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZT", time.Second, "U2"),
+		newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZS", time.Second, "U1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// U1's events in the second batch are delivered in order with the waiting ones.
+	if err := queue.Push([]*Event{
+		newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZV", 4*time.Second, "U1"),
+		newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZQ", 500*time.Millisecond, "U1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	queue.Close()
+	if err := queue.Push([]*Event{newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZW", 0, "U1")}); err != ErrEventQueueClosed {
+		t.Errorf("Push after Close %v; want %v", err, ErrEventQueueClosed)
+	}
+
+	want := map[string][]string{
+		"U1": {
+			"01FZ74A0TDDPYRVKNK77XKC3ZP",
+			"01FZ74A0TDDPYRVKNK77XKC3ZQ",
+			"01FZ74A0TDDPYRVKNK77XKC3ZS",
+			"01FZ74A0TDDPYRVKNK77XKC3ZR",
+			"01FZ74A0TDDPYRVKNK77XKC3ZV",
+		},
+		"U2": {"01FZ74A0TDDPYRVKNK77XKC3ZT"},
+	}
+	got := map[string][]string{}
+	for event := range queue.Events() {
+		got[event.Source.UserID] = append(got[event.Source.UserID], event.WebhookEventID)
+		queue.Done(event)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events %v; want %v", got, want)
+	}
+}
+
+func TestEventQueueBusySource(t *testing.T) {
+	queue := NewEventQueue(10)
+	first := newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZP", 0, "U1")
+	second := newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZQ", time.Second, "U1")
+	other := newQueueTestEvent("01FZ74A0TDDPYRVKNK77XKC3ZR", 2*time.Second, "U2")
+	if err := queue.Push([]*Event{first, second, other}); err != nil {
+		t.Fatal(err)
+	}
+	receive := func() *Event {
+		select {
+		case event := <-queue.Events():
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("no event is delivered")
+			return nil
+		}
+	}
+	// U2's event is delivered while U1's first one is being processed.
+	got := map[*Event]bool{receive(): true, receive(): true}
+	if !got[first] || !got[other] {
+		t.Errorf("events %v; want %v and %v", got, first, other)
+	}
+	select {
+	case event := <-queue.Events():
+		t.Errorf("event %v is delivered before Done", event)
+	case <-time.After(10 * time.Millisecond):
+	}
+	queue.Done(first)
+	if event := receive(); event != second {
+		t.Errorf("event %v; want %v", event, second)
+	}
+	queue.Done(second)
+	queue.Done(other)
+	queue.Close()
+	if _, ok := <-queue.Events(); ok {
+		t.Error("Events is not closed")
+	}
+}
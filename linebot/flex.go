@@ -0,0 +1,411 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// FlexContainerType type
+type FlexContainerType string
+
+// FlexContainerType constants
+const (
+	FlexContainerTypeBubble   FlexContainerType = "bubble"
+	FlexContainerTypeCarousel FlexContainerType = "carousel"
+)
+
+// FlexComponentType type
+type FlexComponentType string
+
+// FlexComponentType constants
+const (
+	FlexComponentTypeBox       FlexComponentType = "box"
+	FlexComponentTypeText      FlexComponentType = "text"
+	FlexComponentTypeSeparator FlexComponentType = "separator"
+)
+
+// FlexBoxLayoutType type
+type FlexBoxLayoutType string
+
+// FlexBoxLayoutType constants
+const (
+	FlexBoxLayoutTypeHorizontal FlexBoxLayoutType = "horizontal"
+	FlexBoxLayoutTypeVertical   FlexBoxLayoutType = "vertical"
+	FlexBoxLayoutTypeBaseline   FlexBoxLayoutType = "baseline"
+)
+
+// FlexComponentSpacingType type
+type FlexComponentSpacingType string
+
+// FlexComponentSpacingType constants
+const (
+	FlexComponentSpacingTypeNone FlexComponentSpacingType = "none"
+	FlexComponentSpacingTypeXs   FlexComponentSpacingType = "xs"
+	FlexComponentSpacingTypeSm   FlexComponentSpacingType = "sm"
+	FlexComponentSpacingTypeMd   FlexComponentSpacingType = "md"
+	FlexComponentSpacingTypeLg   FlexComponentSpacingType = "lg"
+	FlexComponentSpacingTypeXl   FlexComponentSpacingType = "xl"
+	FlexComponentSpacingTypeXxl  FlexComponentSpacingType = "xxl"
+)
+
+// FlexComponentMarginType type
+type FlexComponentMarginType string
+
+// FlexComponentMarginType constants
+const (
+	FlexComponentMarginTypeNone FlexComponentMarginType = "none"
+	FlexComponentMarginTypeXs   FlexComponentMarginType = "xs"
+	FlexComponentMarginTypeSm   FlexComponentMarginType = "sm"
+	FlexComponentMarginTypeMd   FlexComponentMarginType = "md"
+	FlexComponentMarginTypeLg   FlexComponentMarginType = "lg"
+	FlexComponentMarginTypeXl   FlexComponentMarginType = "xl"
+	FlexComponentMarginTypeXxl  FlexComponentMarginType = "xxl"
+)
+
+// FlexTextSizeType type
+type FlexTextSizeType string
+
+// FlexTextSizeType constants
+const (
+	FlexTextSizeTypeXxs FlexTextSizeType = "xxs"
+	FlexTextSizeTypeXs  FlexTextSizeType = "xs"
+	FlexTextSizeTypeSm  FlexTextSizeType = "sm"
+	FlexTextSizeTypeMd  FlexTextSizeType = "md"
+	FlexTextSizeTypeLg  FlexTextSizeType = "lg"
+	FlexTextSizeTypeXl  FlexTextSizeType = "xl"
+	FlexTextSizeTypeXxl FlexTextSizeType = "xxl"
+)
+
+// FlexTextWeightType type
+type FlexTextWeightType string
+
+// FlexTextWeightType constants
+const (
+	FlexTextWeightTypeRegular FlexTextWeightType = "regular"
+	FlexTextWeightTypeBold    FlexTextWeightType = "bold"
+)
+
+// FlexComponentAlignType type
+type FlexComponentAlignType string
+
+// FlexComponentAlignType constants
+const (
+	FlexComponentAlignTypeStart  FlexComponentAlignType = "start"
+	FlexComponentAlignTypeEnd    FlexComponentAlignType = "end"
+	FlexComponentAlignTypeCenter FlexComponentAlignType = "center"
+)
+
+// FlexContainer interface
+type FlexContainer interface {
+	json.Marshaler
+	flexContainer()
+}
+
+// FlexComponent interface
+type FlexComponent interface {
+	json.Marshaler
+	flexComponent()
+}
+
+// BubbleContainer type
+type BubbleContainer struct {
+	Header *BoxComponent
+	Body   *BoxComponent
+	Footer *BoxComponent
+}
+
+// MarshalJSON method of BubbleContainer
+func (c *BubbleContainer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type   FlexContainerType `json:"type"`
+		Header *BoxComponent     `json:"header,omitempty"`
+		Body   *BoxComponent     `json:"body,omitempty"`
+		Footer *BoxComponent     `json:"footer,omitempty"`
+	}{
+		Type:   FlexContainerTypeBubble,
+		Header: c.Header,
+		Body:   c.Body,
+		Footer: c.Footer,
+	})
+}
+
+// CarouselContainer type
+type CarouselContainer struct {
+	Contents []*BubbleContainer
+}
+
+// MarshalJSON method of CarouselContainer
+func (c *CarouselContainer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type     FlexContainerType  `json:"type"`
+		Contents []*BubbleContainer `json:"contents"`
+	}{
+		Type:     FlexContainerTypeCarousel,
+		Contents: c.Contents,
+	})
+}
+
+// BoxComponent type
+type BoxComponent struct {
+	Layout   FlexBoxLayoutType
+	Contents []FlexComponent
+	Spacing  FlexComponentSpacingType
+	Margin   FlexComponentMarginType
+}
+
+// MarshalJSON method of BoxComponent
+func (c *BoxComponent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type     FlexComponentType        `json:"type"`
+		Layout   FlexBoxLayoutType        `json:"layout"`
+		Contents []FlexComponent          `json:"contents"`
+		Spacing  FlexComponentSpacingType `json:"spacing,omitempty"`
+		Margin   FlexComponentMarginType  `json:"margin,omitempty"`
+	}{
+		Type:     FlexComponentTypeBox,
+		Layout:   c.Layout,
+		Contents: c.Contents,
+		Spacing:  c.Spacing,
+		Margin:   c.Margin,
+	})
+}
+
+// TextComponent type
+type TextComponent struct {
+	Text   string
+	Size   FlexTextSizeType
+	Weight FlexTextWeightType
+	Color  string
+	Align  FlexComponentAlignType
+	Margin FlexComponentMarginType
+	Wrap   bool
+}
+
+// MarshalJSON method of TextComponent
+func (c *TextComponent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type   FlexComponentType       `json:"type"`
+		Text   string                  `json:"text"`
+		Size   FlexTextSizeType        `json:"size,omitempty"`
+		Weight FlexTextWeightType      `json:"weight,omitempty"`
+		Color  string                  `json:"color,omitempty"`
+		Align  FlexComponentAlignType  `json:"align,omitempty"`
+		Margin FlexComponentMarginType `json:"margin,omitempty"`
+		Wrap   bool                    `json:"wrap,omitempty"`
+	}{
+		Type:   FlexComponentTypeText,
+		Text:   c.Text,
+		Size:   c.Size,
+		Weight: c.Weight,
+		Color:  c.Color,
+		Align:  c.Align,
+		Margin: c.Margin,
+		Wrap:   c.Wrap,
+	})
+}
+
+// SeparatorComponent type
+type SeparatorComponent struct {
+	Margin FlexComponentMarginType
+	Color  string
+}
+
+// MarshalJSON method of SeparatorComponent
+func (c *SeparatorComponent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type   FlexComponentType       `json:"type"`
+		Margin FlexComponentMarginType `json:"margin,omitempty"`
+		Color  string                  `json:"color,omitempty"`
+	}{
+		Type:   FlexComponentTypeSeparator,
+		Margin: c.Margin,
+		Color:  c.Color,
+	})
+}
+
+// implements FlexContainer interface
+func (*BubbleContainer) flexContainer()   {}
+func (*CarouselContainer) flexContainer() {}
+
+// implements FlexComponent interface
+func (*BoxComponent) flexComponent()       {}
+func (*TextComponent) flexComponent()      {}
+func (*SeparatorComponent) flexComponent() {}
+
+// ReceiptItem type
+type ReceiptItem struct {
+	Name  string
+	Price string
+}
+
+// NewReceiptBubble function
+// It lays out `lineItems` with their prices aligned to the right, followed by a separator and `total`.
+func NewReceiptBubble(title string, lineItems []ReceiptItem, total string) *BubbleContainer {
+	items := make([]FlexComponent, 0, len(lineItems))
+	for _, item := range lineItems {
+		items = append(items, newReceiptRow(item.Name, item.Price, ""))
+	}
+	return &BubbleContainer{
+		Body: &BoxComponent{
+			Layout: FlexBoxLayoutTypeVertical,
+			Contents: []FlexComponent{
+				&TextComponent{
+					Text:   title,
+					Size:   FlexTextSizeTypeXl,
+					Weight: FlexTextWeightTypeBold,
+					Wrap:   true,
+				},
+				&SeparatorComponent{
+					Margin: FlexComponentMarginTypeXxl,
+				},
+				&BoxComponent{
+					Layout:   FlexBoxLayoutTypeVertical,
+					Contents: items,
+					Spacing:  FlexComponentSpacingTypeSm,
+					Margin:   FlexComponentMarginTypeXxl,
+				},
+				&SeparatorComponent{
+					Margin: FlexComponentMarginTypeXxl,
+				},
+				newReceiptRow("TOTAL", total, FlexComponentMarginTypeXxl),
+			},
+		},
+	}
+}
+
+func newReceiptRow(name, price string, margin FlexComponentMarginType) *BoxComponent {
+	return &BoxComponent{
+		Layout: FlexBoxLayoutTypeHorizontal,
+		Contents: []FlexComponent{
+			&TextComponent{
+				Text:  name,
+				Size:  FlexTextSizeTypeSm,
+				Color: "#555555",
+			},
+			&TextComponent{
+				Text:  price,
+				Size:  FlexTextSizeTypeSm,
+				Color: "#111111",
+				Align: FlexComponentAlignTypeEnd,
+			},
+		},
+		Margin: margin,
+	}
+}
+
+// FlexComponentIndex type
+// It locates the flex component pointed to by the `Property` of an error
+// detail. `Bubble` is the index in a carousel, 0 for a bubble. `Contents` has
+// the index in the contents of each nested box, starting from the `Block` box.
+type FlexComponentIndex struct {
+	Message  int
+	Bubble   int
+	Block    string
+	Contents []int
+}
+
+// ParseFlexComponentIndex function
+// For example "messages[0].contents.contents[1].body.contents[2].contents[0].text"
+// is the first component of the third component of the body of the second
+// bubble in the first message. It returns false if `property` doesn't point
+// into a flex component.
+func ParseFlexComponentIndex(property string) (*FlexComponentIndex, bool) {
+	parts := strings.Split(property, ".")
+	if len(parts) < 3 || parts[1] != "contents" {
+		return nil, false
+	}
+	message, ok := parseIndexedProperty(parts[0], "messages")
+	if !ok {
+		return nil, false
+	}
+	index := &FlexComponentIndex{Message: message}
+	parts = parts[2:]
+	if bubble, ok := parseIndexedProperty(parts[0], "contents"); ok {
+		index.Bubble = bubble
+		parts = parts[1:]
+	}
+	if len(parts) == 0 {
+		return nil, false
+	}
+	switch parts[0] {
+	case "header", "body", "footer":
+		index.Block = parts[0]
+	default:
+		return nil, false
+	}
+	for _, part := range parts[1:] {
+		i, ok := parseIndexedProperty(part, "contents")
+		if !ok {
+			break
+		}
+		index.Contents = append(index.Contents, i)
+	}
+	return index, true
+}
+
+// Component method of FlexComponentIndex
+// It returns the component in `container`, or nil if there is no such component.
+func (index *FlexComponentIndex) Component(container FlexContainer) FlexComponent {
+	var bubble *BubbleContainer
+	switch c := container.(type) {
+	case *BubbleContainer:
+		if index.Bubble == 0 {
+			bubble = c
+		}
+	case *CarouselContainer:
+		if index.Bubble < len(c.Contents) {
+			bubble = c.Contents[index.Bubble]
+		}
+	}
+	if bubble == nil {
+		return nil
+	}
+	var box *BoxComponent
+	switch index.Block {
+	case "header":
+		box = bubble.Header
+	case "body":
+		box = bubble.Body
+	case "footer":
+		box = bubble.Footer
+	}
+	if box == nil {
+		return nil
+	}
+	var component FlexComponent = box
+	for _, i := range index.Contents {
+		box, ok := component.(*BoxComponent)
+		if !ok || i >= len(box.Contents) {
+			return nil
+		}
+		component = box.Contents[i]
+	}
+	return component
+}
+
+// parseIndexedProperty parses `s` as "<name>[<index>]".
+func parseIndexedProperty(s, name string) (int, bool) {
+	if !strings.HasPrefix(s, name+"[") || !strings.HasSuffix(s, "]") {
+		return 0, false
+	}
+	i, err := strconv.Atoi(s[len(name)+1 : len(s)-1])
+	if err != nil || i < 0 {
+		return 0, false
+	}
+	return i, true
+}
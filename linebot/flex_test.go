@@ -0,0 +1,178 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewReceiptBubble(t *testing.T) {
+	bubble := NewReceiptBubble("RECEIPT", []ReceiptItem{
+		{Name: "Energy Drink", Price: "$2.99"},
+		{Name: "Chewing Gum", Price: "$0.99"},
+	}, "$3.98")
+	body := bubble.Body.Contents
+	if len(body) != 5 {
+		t.Fatalf("Body contents %d; want %d", len(body), 5)
+	}
+	if title, ok := body[0].(*TextComponent); !ok || title.Text != "RECEIPT" {
+		t.Errorf("title %#v; want RECEIPT", body[0])
+	}
+	items, ok := body[2].(*BoxComponent)
+	if !ok {
+		t.Fatalf("items %T; want *BoxComponent", body[2])
+	}
+	wantItems := [][2]string{{"Energy Drink", "$2.99"}, {"Chewing Gum", "$0.99"}}
+	if len(items.Contents) != len(wantItems) {
+		t.Fatalf("items %d; want %d", len(items.Contents), len(wantItems))
+	}
+	for i, want := range wantItems {
+		row := items.Contents[i].(*BoxComponent)
+		name := row.Contents[0].(*TextComponent)
+		price := row.Contents[1].(*TextComponent)
+		if name.Text != want[0] || price.Text != want[1] {
+			t.Errorf("item %d %s %s; want %s %s", i, name.Text, price.Text, want[0], want[1])
+		}
+	}
+	total := body[4].(*BoxComponent).Contents[1].(*TextComponent)
+	if total.Text != "$3.98" {
+		t.Errorf("total %s; want %s", total.Text, "$3.98")
+	}
+}
+
+func TestFlexMessage(t *testing.T) {
+	message := NewFlexMessage("Hello", &BubbleContainer{
+		Body: &BoxComponent{
+			Layout: FlexBoxLayoutTypeVertical,
+			Contents: []FlexComponent{
+				&TextComponent{Text: "Hello,"},
+				&SeparatorComponent{},
+				&TextComponent{Text: "World!", Weight: FlexTextWeightTypeBold},
+			},
+		},
+	})
+	got, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"flex","altText":"Hello","contents":{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"Hello,"},{"type":"separator"},{"type":"text","text":"World!","weight":"bold"}]}}}`
+	if string(got) != want {
+		t.Errorf("FlexMessage %s; want %s", got, want)
+	}
+}
+
+func TestParseFlexComponentIndex(t *testing.T) {
+	var testCases = []struct {
+		Property string
+		Want     *FlexComponentIndex
+	}{
+		{
+			Property: "messages[0].contents.body.contents[2].text",
+			Want:     &FlexComponentIndex{Message: 0, Block: "body", Contents: []int{2}},
+		},
+		{
+			Property: "messages[1].contents.contents[3].footer.contents[0].contents[1].color",
+			Want:     &FlexComponentIndex{Message: 1, Bubble: 3, Block: "footer", Contents: []int{0, 1}},
+		},
+		{
+			Property: "messages[0].contents.header.layout",
+			Want:     &FlexComponentIndex{Message: 0, Block: "header"},
+		},
+		{
+			Property: "messages[0].text",
+		},
+		{
+			Property: "messages[0].contents.contents[1]",
+		},
+		{
+			Property: "to",
+		},
+	}
+	for i, tc := range testCases {
+		got, ok := ParseFlexComponentIndex(tc.Property)
+		if ok != (tc.Want != nil) {
+			t.Errorf("ok %d %v; want %v", i, ok, tc.Want != nil)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("FlexComponentIndex %d %+v; want %+v", i, got, tc.Want)
+		}
+	}
+}
+
+func TestFlexErrorDetail(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"A message (messages[0]) in the request body is invalid","details":[{"message":"must be specified","property":"messages[0].contents.contents[1].body.contents[1].contents[0].text"}]}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := &TextComponent{}
+	message := NewFlexMessage("Hello", &CarouselContainer{
+		Contents: []*BubbleContainer{
+			{
+				Body: &BoxComponent{
+					Layout:   FlexBoxLayoutTypeVertical,
+					Contents: []FlexComponent{&TextComponent{Text: "first"}},
+				},
+			},
+			{
+				Body: &BoxComponent{
+					Layout: FlexBoxLayoutTypeVertical,
+					Contents: []FlexComponent{
+						&TextComponent{Text: "second"},
+						&BoxComponent{
+							Layout:   FlexBoxLayoutTypeHorizontal,
+							Contents: []FlexComponent{invalid},
+						},
+					},
+				},
+			},
+		},
+	})
+	_, err = client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", message).Do()
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("err %v; want *APIError", err)
+	}
+	property := "messages[0].contents.contents[1].body.contents[1].contents[0].text"
+	if !strings.Contains(apiErr.Error(), "["+property+"] must be specified") {
+		t.Errorf("Error %q; want the detail of %s", apiErr.Error(), property)
+	}
+	detail := apiErr.Response.DetailFor(property)
+	if detail == nil {
+		t.Fatalf("DetailFor %s nil", property)
+	}
+	index, ok := ParseFlexComponentIndex(detail.Property)
+	if !ok {
+		t.Fatalf("ParseFlexComponentIndex %s failed", detail.Property)
+	}
+	if got := index.Component(message.Contents); got != invalid {
+		t.Errorf("Component %v; want %v", got, invalid)
+	}
+	index.Contents = append(index.Contents, 0)
+	if got := index.Component(message.Contents); got != nil {
+		t.Errorf("Component %v; want nil", got)
+	}
+}
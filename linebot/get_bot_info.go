@@ -0,0 +1,66 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// GetBotInfo method
+func (client *Client) GetBotInfo() *GetBotInfoCall {
+	return &GetBotInfoCall{
+		c: client,
+	}
+}
+
+// GetBotInfoCall type
+type GetBotInfoCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetBotInfoCall) WithContext(ctx context.Context) *GetBotInfoCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetBotInfoCall) Do() (*BotInfoResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointGetBotInfo, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBotInfoResponse(res, call.c.codec)
+}
+
+// Handle method of BotInfoResponse
+// It returns the ID to search for the bot in the form of "@id",
+// which is the premium ID if it is set and otherwise the basic ID.
+func (b *BotInfoResponse) Handle() string {
+	id := b.PremiumID
+	if id == "" {
+		id = b.BasicID
+	}
+	if id == "" || strings.HasPrefix(id, "@") {
+		return id
+	}
+	return "@" + id
+}
@@ -0,0 +1,134 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestGetBotInfo(t *testing.T) {
+	type want struct {
+		Response *BotInfoResponse
+		Error    error
+	}
+	var testCases = []struct {
+		ResponseCode int
+		Response     []byte
+		Want         want
+	}{
+		{
+			ResponseCode: 200,
+			Response:     []byte(`{"userId":"Ub9952f8c7f4cb6df9e6c4c7de1e2e4e7","basicId":"@216ru...","premiumId":"@example","displayName":"Example name","pictureUrl":"https://obs.line-apps.com/...","chatMode":"chat","markAsReadMode":"manual"}`),
+			Want: want{
+				Response: &BotInfoResponse{
+					UserID:         "Ub9952f8c7f4cb6df9e6c4c7de1e2e4e7",
+					BasicID:        "@216ru...",
+					PremiumID:      "@example",
+					DisplayName:    "Example name",
+					PictureURL:     "https://obs.line-apps.com/...",
+					ChatMode:       ChatModeChat,
+					MarkAsReadMode: MarkAsReadModeManual,
+				},
+			},
+		},
+		{
+			// Internal server error
+			ResponseCode: 500,
+			Response:     []byte("500 Internal server error"),
+			Want: want{
+				Error: &APIError{
+					Code: 500,
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != APIEndpointGetBotInfo {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointGetBotInfo)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := client.GetBotInfo().Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestGetBotInfoWithContext(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = client.GetBotInfo().WithContext(ctx).Do()
+	if err != context.DeadlineExceeded {
+		t.Errorf("err %v; want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestBotInfoResponseHandle(t *testing.T) {
+	var testCases = []struct {
+		BotInfo *BotInfoResponse
+		Want    string
+	}{
+		{BotInfo: &BotInfoResponse{BasicID: "@216ru..."}, Want: "@216ru..."},
+		{BotInfo: &BotInfoResponse{BasicID: "216ru..."}, Want: "@216ru..."},
+		{BotInfo: &BotInfoResponse{BasicID: "@216ru...", PremiumID: "@linedevelopers"}, Want: "@linedevelopers"},
+		{BotInfo: &BotInfoResponse{}, Want: ""},
+	}
+	for i, tc := range testCases {
+		if got := tc.BotInfo.Handle(); got != tc.Want {
+			t.Errorf("Handle %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
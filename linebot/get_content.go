@@ -16,6 +16,12 @@ package linebot
 
 import (
 	"fmt"
+	"image"
+	_ "image/jpeg" // register the JPEG decoder for DecodeImage
+	_ "image/png"  // register the PNG decoder for DecodeImage
+	"io"
+	"io/ioutil"
+	"net/http"
 
 	"golang.org/x/net/context"
 )
@@ -28,12 +34,25 @@ func (client *Client) GetMessageContent(messageID string) *GetMessageContentCall
 	}
 }
 
+// GetMessageContentStream method
+// `onProgress` is called as the content is read with the number of bytes read
+// so far and the content length, which is -1 when unknown.
+func (client *Client) GetMessageContentStream(messageID string, onProgress func(downloaded, total int64)) *GetMessageContentCall {
+	return &GetMessageContentCall{
+		c:          client,
+		messageID:  messageID,
+		onProgress: onProgress,
+	}
+}
+
 // GetMessageContentCall type
 type GetMessageContentCall struct {
 	c   *Client
 	ctx context.Context
 
-	messageID string
+	messageID  string
+	byteRange  string
+	onProgress func(downloaded, total int64)
 }
 
 // WithContext method
@@ -42,12 +61,91 @@ func (call *GetMessageContentCall) WithContext(ctx context.Context) *GetMessageC
 	return call
 }
 
+// WithRange method
+// It requests the bytes from `start` to `end` inclusive, for resuming a download.
+// A negative `end` requests the rest of the content. ErrRangeIgnored is returned
+// if the whole content is sent instead.
+func (call *GetMessageContentCall) WithRange(start, end int64) *GetMessageContentCall {
+	if end < 0 {
+		call.byteRange = fmt.Sprintf("bytes=%d-", start)
+	} else {
+		call.byteRange = fmt.Sprintf("bytes=%d-%d", start, end)
+	}
+	return call
+}
+
 // Do method
 func (call *GetMessageContentCall) Do() (*MessageContentResponse, error) {
 	endpoint := fmt.Sprintf(APIEndpointGetMessageContent, call.messageID)
-	res, err := call.c.get(call.ctx, endpoint)
+	req, err := http.NewRequest("GET", call.c.url(endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+	if call.byteRange != "" {
+		req.Header.Set("Range", call.byteRange)
+	}
+	res, err := call.c.do(call.ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	if call.byteRange != "" && res.StatusCode == http.StatusOK {
+		res.Body.Close()
+		return nil, ErrRangeIgnored
+	}
+	result, err := decodeToMessageContentResponse(res)
 	if err != nil {
 		return nil, err
 	}
-	return decodeToMessageContentResponse(res)
+	if call.onProgress != nil {
+		result.Content = &progressReader{
+			ReadCloser: result.Content,
+			total:      result.ContentLength,
+			onProgress: call.onProgress,
+		}
+	}
+	return result, nil
+}
+
+// progressReader reports the number of bytes read to onProgress.
+type progressReader struct {
+	io.ReadCloser
+	downloaded int64
+	total      int64
+	onProgress func(downloaded, total int64)
+}
+
+// Read method of progressReader
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.downloaded += int64(n)
+		r.onProgress(r.downloaded, r.total)
+	}
+	return n, err
+}
+
+// ReadAllLimit method of MessageContentResponse
+// It reads the content up to `max` bytes and closes it. ErrContentTooLarge is
+// returned without reading the rest when the content is larger than `max`.
+func (r *MessageContentResponse) ReadAllLimit(max int64) ([]byte, error) {
+	defer r.Content.Close()
+	if r.ContentLength > max {
+		return nil, ErrContentTooLarge
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(r.Content, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > max {
+		return nil, ErrContentTooLarge
+	}
+	return b, nil
+}
+
+// DecodeImage method of MessageContentResponse
+// It decodes the content as a PNG or JPEG image and closes it. The returned
+// string is the format name, e.g. "png" or "jpeg".
+func (r *MessageContentResponse) DecodeImage() (image.Image, string, error) {
+	defer r.Content.Close()
+	return image.Decode(r.Content)
 }
@@ -15,7 +15,12 @@
 package linebot
 
 import (
+	"bytes"
 	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -59,6 +64,25 @@ func TestGetMessageContent(t *testing.T) {
 				ResponseContent: []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10},
 			},
 		},
+		{
+			MessageID:    "325708",
+			ResponseCode: 200,
+			Response:     []byte("hello"),
+			ResponseHeader: map[string]string{
+				"Content-Type":        "text/plain",
+				"Content-Disposition": `attachment; filename="hello.txt"`,
+			},
+			Want: want{
+				URLPath:     fmt.Sprintf(APIEndpointGetMessageContent, "325708"),
+				RequestBody: []byte(""),
+				Response: &MessageContentResponse{
+					ContentType:   "text/plain",
+					ContentLength: 5,
+					FileName:      "hello.txt",
+				},
+				ResponseContent: []byte("hello"),
+			},
+		},
 		{
 			// 503 Service Unavailable
 			MessageID:    "325708",
@@ -132,6 +156,138 @@ func TestGetMessageContent(t *testing.T) {
 	}
 }
 
+func TestGetMessageContentWithRange(t *testing.T) {
+	content := []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if got := r.Header.Get("Range"); got != "bytes=2-4" {
+			t.Errorf("Range %s; want %s", got, "bytes=2-4")
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("Content-Range", "bytes 2-4/6")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(content[2:5])
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetMessageContent("325708").WithRange(2, 4).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Content.Close()
+	got, err := ioutil.ReadAll(res.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, content[2:5]) {
+		t.Errorf("Content %X; want %X", got, content[2:5])
+	}
+	if res.ContentLength != 3 {
+		t.Errorf("ContentLength %d; want %d", res.ContentLength, 3)
+	}
+}
+
+func TestGetMessageContentWithRangeIgnored(t *testing.T) {
+	content := []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write(content)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetMessageContent("325708").WithRange(2, 4).Do()
+	if err != ErrRangeIgnored {
+		t.Errorf("Error %v; want %v", err, ErrRangeIgnored)
+	}
+	if res != nil {
+		t.Errorf("Response %v; want nil", res)
+	}
+}
+
+func TestGetMessageContentLength(t *testing.T) {
+	content := bytes.Repeat([]byte{0xff}, 4096)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetMessageContent("325708").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Content.Close()
+	if res.ContentLength != int64(len(content)) {
+		t.Errorf("ContentLength %d; want %d", res.ContentLength, len(content))
+	}
+	got, err := ioutil.ReadAll(res.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(got)) != res.ContentLength {
+		t.Errorf("read %d bytes; want %d", len(got), res.ContentLength)
+	}
+}
+
+func TestGetMessageContentStream(t *testing.T) {
+	content := bytes.Repeat([]byte{0xff}, 10000)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.URL.Path != fmt.Sprintf(APIEndpointGetMessageContent, "325708") {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, fmt.Sprintf(APIEndpointGetMessageContent, "325708"))
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var progress []int64
+	res, err := client.GetMessageContentStream("325708", func(downloaded, total int64) {
+		if total != int64(len(content)) {
+			t.Errorf("total %d; want %d", total, len(content))
+		}
+		progress = append(progress, downloaded)
+	}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Content.Close()
+	buf := make([]byte, 1024)
+	for {
+		_, err := res.Content.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(progress) < 10 {
+		t.Errorf("progress reported %d times; want at least 10", len(progress))
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i] <= progress[i-1] {
+			t.Errorf("progress %v; want increasing", progress)
+			break
+		}
+	}
+	if len(progress) > 0 && progress[len(progress)-1] != int64(len(content)) {
+		t.Errorf("downloaded %d; want %d", progress[len(progress)-1], len(content))
+	}
+}
+
 func TestGetMessageContentWithContext(t *testing.T) {
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
@@ -168,3 +324,67 @@ func BenchmarkGetMessageContent(b *testing.B) {
 		ioutil.ReadAll(res.Content)
 	}
 }
+
+func TestMessageContentResponseDecodeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 3))
+	src.Set(1, 2, color.RGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+	res := &MessageContentResponse{
+		Content:       ioutil.NopCloser(&buf),
+		ContentType:   "image/png",
+		ContentLength: int64(buf.Len()),
+	}
+	img, format, err := res.DecodeImage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "png" {
+		t.Errorf("format %s; want png", format)
+	}
+	if img.Bounds() != src.Bounds() {
+		t.Errorf("Bounds %v; want %v", img.Bounds(), src.Bounds())
+	}
+	if r, _, _, _ := img.At(1, 2).RGBA(); r != 0xffff {
+		t.Errorf("At(1, 2) %v; want red", img.At(1, 2))
+	}
+
+	// Not an image
+	res = &MessageContentResponse{
+		Content: ioutil.NopCloser(bytes.NewReader([]byte("not an image"))),
+	}
+	if _, _, err := res.DecodeImage(); err != image.ErrFormat {
+		t.Errorf("err %v; want %v", err, image.ErrFormat)
+	}
+}
+
+func TestMessageContentResponseReadAllLimit(t *testing.T) {
+	content := []byte("0123456789")
+	var testCases = []struct {
+		ContentLength int64
+		Max           int64
+		Want          []byte
+		Error         error
+	}{
+		{ContentLength: 10, Max: 10, Want: content},
+		{ContentLength: 10, Max: 9, Error: ErrContentTooLarge},
+		// Content-Length is unknown
+		{ContentLength: -1, Max: 10, Want: content},
+		{ContentLength: -1, Max: 9, Error: ErrContentTooLarge},
+	}
+	for i, tc := range testCases {
+		res := &MessageContentResponse{
+			Content:       ioutil.NopCloser(bytes.NewReader(content)),
+			ContentLength: tc.ContentLength,
+		}
+		got, err := res.ReadAllLimit(tc.Max)
+		if err != tc.Error {
+			t.Errorf("Error %d %v; want %v", i, err, tc.Error)
+		}
+		if !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("Content %d %q; want %q", i, got, tc.Want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// GetNumberReplyMessages method
+// `date` is in the format of yyyyMMdd in UTC+9.
+func (client *Client) GetNumberReplyMessages(date string) *GetNumberMessagesCall {
+	return &GetNumberMessagesCall{
+		c:        client,
+		endpoint: APIEndpointGetNumberReplyMessages,
+		date:     date,
+	}
+}
+
+// GetNumberPushMessages method
+// `date` is in the format of yyyyMMdd in UTC+9.
+func (client *Client) GetNumberPushMessages(date string) *GetNumberMessagesCall {
+	return &GetNumberMessagesCall{
+		c:        client,
+		endpoint: APIEndpointGetNumberPushMessages,
+		date:     date,
+	}
+}
+
+// GetNumberMulticastMessages method
+// `date` is in the format of yyyyMMdd in UTC+9.
+func (client *Client) GetNumberMulticastMessages(date string) *GetNumberMessagesCall {
+	return &GetNumberMessagesCall{
+		c:        client,
+		endpoint: APIEndpointGetNumberMulticastMessages,
+		date:     date,
+	}
+}
+
+// GetNumberBroadcastMessages method
+// `date` is in the format of yyyyMMdd in UTC+9.
+func (client *Client) GetNumberBroadcastMessages(date string) *GetNumberMessagesCall {
+	return &GetNumberMessagesCall{
+		c:        client,
+		endpoint: APIEndpointGetNumberBroadcastMessages,
+		date:     date,
+	}
+}
+
+// GetNumberMessagesCall type
+type GetNumberMessagesCall struct {
+	c   *Client
+	ctx context.Context
+
+	endpoint string
+	date     string
+}
+
+// WithContext method
+func (call *GetNumberMessagesCall) WithContext(ctx context.Context) *GetNumberMessagesCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetNumberMessagesCall) Do() (*MessagesNumberResponse, error) {
+	q := url.Values{}
+	q.Set("date", call.date)
+	res, err := call.c.get(call.ctx, call.endpoint, q)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToMessagesNumberResponse(res, call.c.codec)
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestGetNumberMessages(t *testing.T) {
+	type want struct {
+		URLPath  string
+		Date     string
+		Response *MessagesNumberResponse
+		Error    error
+	}
+	var testCases = []struct {
+		Call         func(*Client) *GetNumberMessagesCall
+		ResponseCode int
+		Response     []byte
+		Want         want
+	}{
+		{
+			Call:         func(c *Client) *GetNumberMessagesCall { return c.GetNumberReplyMessages("20170910") },
+			ResponseCode: 200,
+			Response:     []byte(`{"status":"ready","success":10000}`),
+			Want: want{
+				URLPath:  APIEndpointGetNumberReplyMessages,
+				Date:     "20170910",
+				Response: &MessagesNumberResponse{Status: "ready", Success: 10000},
+			},
+		},
+		{
+			Call:         func(c *Client) *GetNumberMessagesCall { return c.GetNumberPushMessages("20170911") },
+			ResponseCode: 200,
+			Response:     []byte(`{"status":"unready"}`),
+			Want: want{
+				URLPath:  APIEndpointGetNumberPushMessages,
+				Date:     "20170911",
+				Response: &MessagesNumberResponse{Status: "unready"},
+			},
+		},
+		{
+			Call:         func(c *Client) *GetNumberMessagesCall { return c.GetNumberMulticastMessages("20170912") },
+			ResponseCode: 200,
+			Response:     []byte(`{"status":"out_of_service"}`),
+			Want: want{
+				URLPath:  APIEndpointGetNumberMulticastMessages,
+				Date:     "20170912",
+				Response: &MessagesNumberResponse{Status: "out_of_service"},
+			},
+		},
+		{
+			Call:         func(c *Client) *GetNumberMessagesCall { return c.GetNumberBroadcastMessages("20170913") },
+			ResponseCode: 200,
+			Response:     []byte(`{"status":"ready","success":2}`),
+			Want: want{
+				URLPath:  APIEndpointGetNumberBroadcastMessages,
+				Date:     "20170913",
+				Response: &MessagesNumberResponse{Status: "ready", Success: 2},
+			},
+		},
+		{
+			// Bad request
+			Call:         func(c *Client) *GetNumberMessagesCall { return c.GetNumberReplyMessages("2017-09-10") },
+			ResponseCode: 400,
+			Response:     []byte(`{"message":"Invalid date"}`),
+			Want: want{
+				URLPath: APIEndpointGetNumberReplyMessages,
+				Date:    "2017-09-10",
+				Error: &APIError{
+					Code: 400,
+					Response: &ErrorResponse{
+						Message: "Invalid date",
+					},
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != tc.Want.URLPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, tc.Want.URLPath)
+		}
+		if date := r.URL.Query().Get("date"); date != tc.Want.Date {
+			t.Errorf("date %s; want %s", date, tc.Want.Date)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := tc.Call(client).Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestGetNumberMessagesWithContext(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{"status":"ready","success":10000}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = client.GetNumberReplyMessages("20170910").WithContext(ctx).Do()
+	if err != context.DeadlineExceeded {
+		t.Errorf("err %v; want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -16,6 +16,7 @@ package linebot
 
 import (
 	"fmt"
+	"sync"
 
 	"golang.org/x/net/context"
 )
@@ -45,12 +46,94 @@ func (call *GetProfileCall) WithContext(ctx context.Context) *GetProfileCall {
 // Do method
 func (call *GetProfileCall) Do() (*UserProfileResponse, error) {
 	endpoint := fmt.Sprintf(APIEndpointGetProfile, call.userID)
-	res, err := call.c.get(call.ctx, endpoint)
+	res, err := call.c.get(call.ctx, endpoint, nil)
 	if res != nil && res.Body != nil {
 		defer res.Body.Close()
 	}
 	if err != nil {
 		return nil, err
 	}
-	return decodeToUserProfileResponse(res)
+	return decodeToUserProfileResponse(res, call.c.codec)
+}
+
+// defaultConcurrency is the default number of requests sent at once by the
+// helpers that fan out to many requests.
+const defaultConcurrency = 10
+
+// GetProfiles method
+// It gets the profiles of `userIDs` with a GetProfile request per user.
+func (client *Client) GetProfiles(userIDs []string) *GetProfilesCall {
+	return &GetProfilesCall{
+		c:           client,
+		userIDs:     userIDs,
+		concurrency: defaultConcurrency,
+	}
+}
+
+// GetProfilesCall type
+type GetProfilesCall struct {
+	c   *Client
+	ctx context.Context
+
+	userIDs     []string
+	concurrency int
+}
+
+// WithContext method
+func (call *GetProfilesCall) WithContext(ctx context.Context) *GetProfilesCall {
+	call.ctx = ctx
+	return call
+}
+
+// WithConcurrency method
+// It sets the number of requests sent at once, which is 10 by default.
+func (call *GetProfilesCall) WithConcurrency(concurrency int) *GetProfilesCall {
+	if concurrency > 0 {
+		call.concurrency = concurrency
+	}
+	return call
+}
+
+// Do method
+// It returns the profiles and the errors by user ID. Every user is requested
+// even if some requests fail, so the errors are empty when all succeed.
+func (call *GetProfilesCall) Do() (map[string]*UserProfileResponse, map[string]error) {
+	profiles := map[string]*UserProfileResponse{}
+	errs := map[string]error{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, call.concurrency)
+	seen := map[string]bool{}
+	for _, userID := range call.userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(userID string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			profile, err := call.getProfile(userID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[userID] = err
+				return
+			}
+			profiles[userID] = profile
+		}(userID)
+	}
+	wg.Wait()
+	return profiles, errs
+}
+
+func (call *GetProfilesCall) getProfile(userID string) (*UserProfileResponse, error) {
+	if err := call.c.acquire(call.ctx); err != nil {
+		return nil, err
+	}
+	defer call.c.release()
+	return call.c.GetProfile(userID).WithContext(call.ctx).Do()
 }
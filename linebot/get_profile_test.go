@@ -20,6 +20,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -54,6 +56,21 @@ func TestGetProfile(t *testing.T) {
 				},
 			},
 		},
+		{
+			// A user without a profile picture
+			UserID:       "U0047556f2e40dba2456887320ba7c76d",
+			ResponseCode: 200,
+			Response:     []byte(`{"userId":"U0047556f2e40dba2456887320ba7c76d","displayName":"BOT API","statusMessage":"Hello, LINE!"}`),
+			Want: want{
+				URLPath:     fmt.Sprintf(APIEndpointGetProfile, "U0047556f2e40dba2456887320ba7c76d"),
+				RequestBody: []byte(""),
+				Response: &UserProfileResponse{
+					UserID:        "U0047556f2e40dba2456887320ba7c76d",
+					DisplayName:   "BOT API",
+					StatusMessage: "Hello, LINE!",
+				},
+			},
+		},
 		{
 			// Internal server error
 			UserID:       "U0047556f2e40dba2456887320ba7c76d",
@@ -146,3 +163,75 @@ func BenchmarkGetProfile(b *testing.B) {
 		client.GetProfile("U0047556f2e40dba2456887320ba7c76d").Do()
 	}
 }
+
+func TestGetProfileMalformedResponse(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Write([]byte(`<html><body>It works!</body></html>`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetProfile("U0047556f2e40dba2456887320ba7c76d").Do()
+	derr, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("Error %v; want *DecodeError", err)
+	}
+	endpoint := fmt.Sprintf(APIEndpointGetProfile, "U0047556f2e40dba2456887320ba7c76d")
+	if derr.Endpoint != endpoint {
+		t.Errorf("Endpoint %s; want %s", derr.Endpoint, endpoint)
+	}
+	if !strings.Contains(err.Error(), endpoint) {
+		t.Errorf("Error %q does not mention %s", err, endpoint)
+	}
+}
+
+func TestGetProfiles(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		userID := strings.TrimPrefix(r.URL.Path, "/v2/bot/profile/")
+		if userID == "Uunknown" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"userId":"%s","displayName":"name of %s"}`, userID, userID)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userIDs := []string{"U1", "U2", "U3", "U4", "U5", "Uunknown"}
+	profiles, errs := client.GetProfiles(userIDs).WithConcurrency(2).Do()
+	for _, userID := range userIDs[:5] {
+		want := &UserProfileResponse{UserID: userID, DisplayName: "name of " + userID}
+		if !reflect.DeepEqual(profiles[userID], want) {
+			t.Errorf("Profile %s %+v; want %+v", userID, profiles[userID], want)
+		}
+	}
+	if len(profiles) != 5 {
+		t.Errorf("Profiles %d; want 5", len(profiles))
+	}
+	want := &APIError{Code: 404, Response: &ErrorResponse{Message: "Not found"}}
+	if len(errs) != 1 || !reflect.DeepEqual(errs["Uunknown"], want) {
+		t.Errorf("Errors %v; want %v for Uunknown", errs, want)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("requests in flight %d; want at most 2", maxInFlight)
+	}
+}
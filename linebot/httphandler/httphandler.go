@@ -17,10 +17,16 @@ package httphandler
 import (
 	"errors"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/line/line-bot-sdk-go/linebot"
 )
 
+// ErrQueueFull is passed to the error handler when a webhook is rejected
+// because the queue of HandleAsync is full.
+var ErrQueueFull = errors.New("webhook queue is full")
+
 // EventsHandlerFunc type
 type EventsHandlerFunc func([]*linebot.Event, *http.Request)
 
@@ -32,8 +38,15 @@ type WebhookHandler struct {
 	channelSecret string
 	channelToken  string
 
-	handleEvents EventsHandlerFunc
-	handleError  ErrorHandlerFunc
+	handleEvents  EventsHandlerFunc
+	handleStandby EventsHandlerFunc
+	handleError   ErrorHandlerFunc
+	skipStandby   bool
+	dedup         *linebot.EventDeduplicator
+	logger        linebot.Logger
+
+	queue   chan func()
+	workers sync.WaitGroup
 }
 
 // New returns a new WebhookHandler instance.
@@ -56,13 +69,85 @@ func (wh *WebhookHandler) HandleEvents(f EventsHandlerFunc) {
 	wh.handleEvents = f
 }
 
+// HandleStandbyEvents method
+// Once set, events received while the channel is in standby mode are passed to
+// `f` instead of the events handler, so that a standby bot does not reply to them.
+func (wh *WebhookHandler) HandleStandbyEvents(f EventsHandlerFunc) {
+	wh.handleStandby = f
+}
+
+// SkipStandbyEvents method
+// Once set, events received while the channel is in standby mode are dropped
+// instead of being passed to the events handler, and logged if a logger is set.
+// By default they are passed to the events handler like the other events.
+func (wh *WebhookHandler) SkipStandbyEvents() {
+	wh.skipStandby = true
+}
+
+// SetLogger method
+// The logger is used for the messages of the handler, and is also set to the
+// clients created by NewClient.
+func (wh *WebhookHandler) SetLogger(l linebot.Logger) {
+	wh.logger = l
+}
+
 // HandleError method
 func (wh *WebhookHandler) HandleError(f ErrorHandlerFunc) {
 	wh.handleError = f
 }
 
+// SkipDuplicateEvents method
+// Once set, events which have been handled within `ttl` are dropped by their
+// webhook event IDs, so that a redelivered webhook is acknowledged with 200
+// without handling its events again.
+func (wh *WebhookHandler) SkipDuplicateEvents(ttl time.Duration) {
+	wh.dedup = linebot.NewEventDeduplicator(ttl)
+}
+
+// HandleAsync method
+// Once set, the webhook is acknowledged before the events are handled, since
+// LINE gives up on a webhook which isn't answered in about a second. The events
+// are handled by `workers` goroutines, and up to `queueSize` webhooks wait for
+// them. When the queue is full, 503 is returned so that the webhook is
+// redelivered if redelivery is enabled. The context of the request passed to
+// the handlers is already canceled; use another context for API calls.
+// Both `workers` and `queueSize` must be at least 1.
+func (wh *WebhookHandler) HandleAsync(workers, queueSize int) error {
+	if workers < 1 {
+		return errors.New("workers must be at least 1")
+	}
+	if queueSize < 1 {
+		return errors.New("queue size must be at least 1")
+	}
+	wh.queue = make(chan func(), queueSize)
+	for i := 0; i < workers; i++ {
+		wh.workers.Add(1)
+		go func() {
+			defer wh.workers.Done()
+			for handle := range wh.queue {
+				handle()
+			}
+		}()
+	}
+	return nil
+}
+
+// Close method
+// It waits until the queued events are handled when HandleAsync is set.
+// It must be called after the server stops serving the handler.
+func (wh *WebhookHandler) Close() {
+	if wh.queue == nil {
+		return
+	}
+	close(wh.queue)
+	wh.workers.Wait()
+}
+
 // NewClient method
 func (wh *WebhookHandler) NewClient(options ...linebot.ClientOption) (*linebot.Client, error) {
+	if wh.logger != nil {
+		options = append([]linebot.ClientOption{linebot.WithLogger(wh.logger)}, options...)
+	}
 	return linebot.New(wh.channelSecret, wh.channelToken, options...)
 }
 
@@ -79,5 +164,58 @@ func (wh *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	wh.handleEvents(events, r)
+	if wh.dedup != nil && len(events) > 0 {
+		events = wh.dedup.Filter(events)
+		if len(events) == 0 {
+			return
+		}
+	}
+	if wh.queue == nil {
+		wh.handle(events, r)
+		return
+	}
+	select {
+	case wh.queue <- func() { wh.handle(events, r) }:
+	default:
+		// the events are handled when the webhook is redelivered
+		if wh.dedup != nil {
+			wh.dedup.Forget(events)
+		}
+		if wh.handleError != nil {
+			wh.handleError(ErrQueueFull, r)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func (wh *WebhookHandler) handle(events []*linebot.Event, r *http.Request) {
+	if wh.handleStandby != nil || wh.skipStandby {
+		var standby []*linebot.Event
+		events, standby = splitStandbyEvents(events)
+		if len(standby) > 0 {
+			if wh.handleStandby != nil {
+				wh.handleStandby(standby, r)
+			} else if wh.logger != nil {
+				wh.logger.Printf("linebot: skipped %d events received in standby mode; another channel has chat control", len(standby))
+			}
+			if len(events) == 0 {
+				return
+			}
+		}
+	}
+	if wh.handleEvents != nil {
+		wh.handleEvents(events, r)
+	}
+}
+
+func splitStandbyEvents(events []*linebot.Event) (active, standby []*linebot.Event) {
+	active = []*linebot.Event{}
+	for _, event := range events {
+		if event.Mode == linebot.EventModeStandby {
+			standby = append(standby, event)
+		} else {
+			active = append(active, event)
+		}
+	}
+	return active, standby
 }
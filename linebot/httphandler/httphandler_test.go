@@ -20,9 +20,13 @@ import (
 	"crypto/sha256"
 	"crypto/tls"
 	"encoding/base64"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/line/line-bot-sdk-go/linebot"
 )
@@ -134,3 +138,400 @@ func TestWebhookHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestWebhookHandlerEmptyEvents(t *testing.T) {
+	handler, err := New(testChannelSecret, testChannelToken)
+	if err != nil {
+		t.Error(err)
+	}
+	var called bool
+	handler.HandleEvents(func(events []*linebot.Event, r *http.Request) {
+		called = true
+		if len(events) != 0 {
+			t.Errorf("events %v; want empty", events)
+		}
+	})
+	handler.HandleError(func(err error, r *http.Request) {
+		t.Errorf("err %v; want nil", err)
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	body := []byte(`{"events":[]}`)
+	req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, []byte(testChannelSecret))
+	mac.Write(body)
+	req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	res, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status: %d", res.StatusCode)
+	}
+	if !called {
+		t.Error("events handler is not called")
+	}
+}
+
+func TestWebhookHandlerStandbyEvents(t *testing.T) {
+	handler, err := New(testChannelSecret, testChannelToken)
+	if err != nil {
+		t.Error(err)
+	}
+	var gotActive, gotStandby []*linebot.Event
+	handler.HandleEvents(func(events []*linebot.Event, r *http.Request) {
+		gotActive = events
+	})
+	handler.HandleStandbyEvents(func(events []*linebot.Event, r *http.Request) {
+		gotStandby = events
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	body := []byte(`{
+    "events": [
+        {
+            "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+            "type": "message",
+            "mode": "active",
+            "timestamp": 1462629479859,
+            "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"},
+            "message": {"id": "325708", "type": "text", "text": "Hello, world"}
+        },
+        {
+            "type": "message",
+            "mode": "standby",
+            "timestamp": 1462629479859,
+            "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"},
+            "message": {"id": "325709", "type": "text", "text": "Hello, standby"}
+        }
+    ]
+}`)
+	req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, []byte(testChannelSecret))
+	mac.Write(body)
+	req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	res, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status: %d", res.StatusCode)
+	}
+	if len(gotActive) != 1 || gotActive[0].Mode != linebot.EventModeActive {
+		t.Errorf("active events %v; want 1 active event", gotActive)
+	}
+	if len(gotStandby) != 1 || gotStandby[0].Mode != linebot.EventModeStandby {
+		t.Errorf("standby events %v; want 1 standby event", gotStandby)
+	}
+}
+
+func TestWebhookHandlerSkipStandbyEvents(t *testing.T) {
+	const (
+		activeEvent  = `{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","type":"message","mode":"active","timestamp":1462629479859,"source":{"type":"user","userId":"u206d25c2ea6bd87c17655609a1c37cb8"},"message":{"id":"325708","type":"text","text":"Hello, world"}}`
+		standbyEvent = `{"type":"message","mode":"standby","timestamp":1462629479859,"source":{"type":"user","userId":"u206d25c2ea6bd87c17655609a1c37cb8"},"message":{"id":"325709","type":"text","text":"Hello, standby"}}`
+	)
+	handler, err := New(testChannelSecret, testChannelToken)
+	if err != nil {
+		t.Error(err)
+	}
+	var handled [][]*linebot.Event
+	handler.HandleEvents(func(events []*linebot.Event, r *http.Request) {
+		handled = append(handled, events)
+	})
+	logger := &testLogger{}
+	handler.SetLogger(logger)
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	post := func(events ...string) {
+		body := []byte(`{"events":[` + strings.Join(events, ",") + `]}`)
+		req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mac := hmac.New(sha256.New, []byte(testChannelSecret))
+		mac.Write(body)
+		req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		res, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("status: %d", res.StatusCode)
+		}
+	}
+
+	// Standby events are handled like the other events by default.
+	post(activeEvent, standbyEvent)
+	if len(handled) != 1 || len(handled[0]) != 2 {
+		t.Fatalf("handled %v; want 2 events", handled)
+	}
+
+	handled = nil
+	handler.SkipStandbyEvents()
+	post(activeEvent, standbyEvent)
+	// The events handler is not called without active events.
+	post(standbyEvent)
+	if len(handled) != 1 || len(handled[0]) != 1 || handled[0][0].Mode != linebot.EventModeActive {
+		t.Errorf("handled %v; want 1 active event", handled)
+	}
+	want := []string{
+		"linebot: skipped 1 events received in standby mode; another channel has chat control",
+		"linebot: skipped 1 events received in standby mode; another channel has chat control",
+	}
+	if !reflect.DeepEqual(logger.lines, want) {
+		t.Errorf("log %v; want %v", logger.lines, want)
+	}
+}
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestWebhookHandlerAsync(t *testing.T) {
+	handler, err := New(testChannelSecret, testChannelToken)
+	if err != nil {
+		t.Error(err)
+	}
+	release := make(chan struct{})
+	handled := make(chan string, 2)
+	handler.HandleEvents(func(events []*linebot.Event, r *http.Request) {
+		<-release
+		handled <- events[0].ReplyToken
+	})
+	var gotErr error
+	handler.HandleError(func(err error, r *http.Request) {
+		gotErr = err
+	})
+	if err := handler.HandleAsync(1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	post := func() int {
+		body := []byte(testRequestBody)
+		req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mac := hmac.New(sha256.New, []byte(testChannelSecret))
+		mac.Write(body)
+		req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		res, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	// The first webhook is taken by the worker, which is blocked until released,
+	// and the second one waits in the queue.
+	for i := 0; i < 2; i++ {
+		if status := post(); status != http.StatusOK {
+			t.Errorf("status %d: %d; want %d", i, status, http.StatusOK)
+		}
+		for i == 0 && len(handler.queue) > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if status := post(); status != http.StatusServiceUnavailable {
+		t.Errorf("status: %d; want %d", status, http.StatusServiceUnavailable)
+	}
+	if gotErr != ErrQueueFull {
+		t.Errorf("error %v; want %v", gotErr, ErrQueueFull)
+	}
+	select {
+	case <-handled:
+		t.Error("events are handled before the webhook is acknowledged")
+	default:
+	}
+
+	close(release)
+	server.Close()
+	handler.Close()
+	if len(handled) != 2 {
+		t.Errorf("handled %d webhooks; want 2", len(handled))
+	}
+}
+
+func TestWebhookHandlerAsyncInvalid(t *testing.T) {
+	handler, err := New(testChannelSecret, testChannelToken)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, tc := range []struct{ Workers, QueueSize int }{{0, 1}, {1, 0}, {1, -1}} {
+		if err := handler.HandleAsync(tc.Workers, tc.QueueSize); err == nil {
+			t.Errorf("HandleAsync(%d, %d): expected an error", tc.Workers, tc.QueueSize)
+		}
+	}
+	if handler.queue != nil {
+		t.Error("queue is set by an invalid HandleAsync")
+	}
+}
+
+func TestWebhookHandlerSkipDuplicateEvents(t *testing.T) {
+	handler, err := New(testChannelSecret, testChannelToken)
+	if err != nil {
+		t.Error(err)
+	}
+	var handled [][]*linebot.Event
+	handler.HandleEvents(func(events []*linebot.Event, r *http.Request) {
+		handled = append(handled, events)
+	})
+	handler.SkipDuplicateEvents(time.Hour)
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	body := []byte(`{
+    "events": [
+        {
+            "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+            "type": "message",
+            "mode": "active",
+            "timestamp": 1462629479859,
+            "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"},
+            "webhookEventId": "01FZ74A0TDDPYRVKNK77XKC3ZR",
+            "message": {"id": "325708", "type": "text", "text": "Hello, world"}
+        }
+    ]
+}`)
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mac := hmac.New(sha256.New, []byte(testChannelSecret))
+		mac.Write(body)
+		req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		res, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("status %d: %d; want %d", i, res.StatusCode, http.StatusOK)
+		}
+	}
+	if len(handled) != 1 || len(handled[0]) != 1 || handled[0][0].WebhookEventID != "01FZ74A0TDDPYRVKNK77XKC3ZR" {
+		t.Errorf("handled %v; want the event once", handled)
+	}
+}
+
+func TestWebhookHandlerSkipDuplicateEventsQueueFull(t *testing.T) {
+	handler, err := New(testChannelSecret, testChannelToken)
+	if err != nil {
+		t.Error(err)
+	}
+	release := make(chan struct{})
+	handled := make(chan string, 4)
+	handler.HandleEvents(func(events []*linebot.Event, r *http.Request) {
+		<-release
+		for _, event := range events {
+			handled <- event.WebhookEventID
+		}
+	})
+	handler.SkipDuplicateEvents(time.Hour)
+	if err := handler.HandleAsync(1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	post := func(webhookEventID string) int {
+		body := []byte(`{"events":[{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","type":"message","mode":"active","timestamp":1462629479859,"source":{"type":"user","userId":"u206d25c2ea6bd87c17655609a1c37cb8"},"webhookEventId":"` + webhookEventID + `","message":{"id":"325708","type":"text","text":"Hello, world"}}]}`)
+		req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mac := hmac.New(sha256.New, []byte(testChannelSecret))
+		mac.Write(body)
+		req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		res, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	// The first webhook is taken by the worker and the second one fills the queue.
+	if status := post("01FZ74A0TDDPYRVKNK77XKC3Z1"); status != http.StatusOK {
+		t.Errorf("status %d; want %d", status, http.StatusOK)
+	}
+	for len(handler.queue) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if status := post("01FZ74A0TDDPYRVKNK77XKC3Z2"); status != http.StatusOK {
+		t.Errorf("status %d; want %d", status, http.StatusOK)
+	}
+	if status := post("01FZ74A0TDDPYRVKNK77XKC3Z3"); status != http.StatusServiceUnavailable {
+		t.Errorf("status %d; want %d", status, http.StatusServiceUnavailable)
+	}
+
+	close(release)
+	for len(handled) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	// The rejected webhook is redelivered.
+	if status := post("01FZ74A0TDDPYRVKNK77XKC3Z3"); status != http.StatusOK {
+		t.Errorf("status %d; want %d", status, http.StatusOK)
+	}
+	server.Close()
+	handler.Close()
+	var got []string
+	for len(handled) > 0 {
+		got = append(got, <-handled)
+	}
+	want := []string{"01FZ74A0TDDPYRVKNK77XKC3Z1", "01FZ74A0TDDPYRVKNK77XKC3Z2", "01FZ74A0TDDPYRVKNK77XKC3Z3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("handled %v; want %v", got, want)
+	}
+}
@@ -34,11 +34,16 @@ type ImagemapBaseSize struct {
 }
 
 // ImagemapArea type
-type ImagemapArea struct {
-	X      int `json:"x"`
-	Y      int `json:"y"`
-	Width  int `json:"width"`
-	Height int `json:"height"`
+type ImagemapArea Bounds
+
+// Validate method of ImagemapArea
+func (a ImagemapArea) Validate() error {
+	return Bounds(a).Validate()
+}
+
+// ValidateWithin method of ImagemapArea
+func (a ImagemapArea) ValidateWithin(width, height int) error {
+	return Bounds(a).ValidateWithin(width, height)
 }
 
 // ImagemapAction type
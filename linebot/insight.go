@@ -0,0 +1,163 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// GetFriendDemographics method
+func (client *Client) GetFriendDemographics() *GetFriendDemographicsCall {
+	return &GetFriendDemographicsCall{
+		c: client,
+	}
+}
+
+// GetFriendDemographicsCall type
+type GetFriendDemographicsCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetFriendDemographicsCall) WithContext(ctx context.Context) *GetFriendDemographicsCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetFriendDemographicsCall) Do() (*FriendDemographicsResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointGetFriendDemographics, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToFriendDemographicsResponse(res, call.c.codec)
+}
+
+// GetNumberOfMessageDeliveries method
+// `date` is in the format of yyyyMMdd in UTC+9.
+func (client *Client) GetNumberOfMessageDeliveries(date string) *GetNumberOfMessageDeliveriesCall {
+	return &GetNumberOfMessageDeliveriesCall{
+		c:    client,
+		date: date,
+	}
+}
+
+// GetNumberOfMessageDeliveriesCall type
+type GetNumberOfMessageDeliveriesCall struct {
+	c   *Client
+	ctx context.Context
+
+	date string
+}
+
+// WithContext method
+func (call *GetNumberOfMessageDeliveriesCall) WithContext(ctx context.Context) *GetNumberOfMessageDeliveriesCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetNumberOfMessageDeliveriesCall) Do() (*MessagesNumberDeliveryResponse, error) {
+	q := url.Values{}
+	q.Set("date", call.date)
+	res, err := call.c.get(call.ctx, APIEndpointGetNumberOfMessageDeliveries, q)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToMessagesNumberDeliveryResponse(res, call.c.codec)
+}
+
+// GetNumberOfFollowers method
+// `date` is in the format of yyyyMMdd in UTC+9.
+func (client *Client) GetNumberOfFollowers(date string) *GetNumberOfFollowersCall {
+	return &GetNumberOfFollowersCall{
+		c:    client,
+		date: date,
+	}
+}
+
+// GetNumberOfFollowersCall type
+type GetNumberOfFollowersCall struct {
+	c   *Client
+	ctx context.Context
+
+	date string
+}
+
+// WithContext method
+func (call *GetNumberOfFollowersCall) WithContext(ctx context.Context) *GetNumberOfFollowersCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetNumberOfFollowersCall) Do() (*MessagesNumberFollowersResponse, error) {
+	q := url.Values{}
+	q.Set("date", call.date)
+	res, err := call.c.get(call.ctx, APIEndpointGetNumberOfFollowers, q)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToMessagesNumberFollowersResponse(res, call.c.codec)
+}
+
+// GetUserInteractionStatistics method
+// `requestID` is the X-Line-Request-Id of a broadcast or narrowcast request.
+func (client *Client) GetUserInteractionStatistics(requestID string) *GetUserInteractionStatisticsCall {
+	return &GetUserInteractionStatisticsCall{
+		c:         client,
+		requestID: requestID,
+	}
+}
+
+// GetUserInteractionStatisticsCall type
+type GetUserInteractionStatisticsCall struct {
+	c   *Client
+	ctx context.Context
+
+	requestID string
+}
+
+// WithContext method
+func (call *GetUserInteractionStatisticsCall) WithContext(ctx context.Context) *GetUserInteractionStatisticsCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetUserInteractionStatisticsCall) Do() (*UserInteractionStatisticsResponse, error) {
+	q := url.Values{}
+	q.Set("requestId", call.requestID)
+	res, err := call.c.get(call.ctx, APIEndpointGetUserInteractionStatistics, q)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToUserInteractionStatisticsResponse(res, call.c.codec)
+}
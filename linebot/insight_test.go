@@ -0,0 +1,470 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetFriendDemographics(t *testing.T) {
+	type want struct {
+		Response *FriendDemographicsResponse
+		Error    error
+	}
+	var testCases = []struct {
+		ResponseCode int
+		Response     []byte
+		Want         want
+	}{
+		{
+			ResponseCode: 200,
+			Response: []byte(`{
+				"available": true,
+				"genders": [
+					{"gender": "unknown", "percentage": 37.6},
+					{"gender": "male", "percentage": 31.8},
+					{"gender": "female", "percentage": 30.6}
+				],
+				"ages": [
+					{"age": "unknown", "percentage": 37.6},
+					{"age": "from50", "percentage": 17.3}
+				],
+				"areas": [
+					{"area": "unknown", "percentage": 42.9},
+					{"area": "徳島", "percentage": 2.9}
+				],
+				"appTypes": [
+					{"appType": "ios", "percentage": 62.4},
+					{"appType": "android", "percentage": 27.7},
+					{"appType": "others", "percentage": 9.9}
+				],
+				"subscriptionPeriods": [
+					{"subscriptionPeriod": "over365days", "percentage": 96.4},
+					{"subscriptionPeriod": "within365days", "percentage": 1.9},
+					{"subscriptionPeriod": "within7days", "percentage": 1.7}
+				]
+			}`),
+			Want: want{
+				Response: &FriendDemographicsResponse{
+					Available: true,
+					Genders: []*GenderRatio{
+						{Gender: "unknown", Percentage: 37.6},
+						{Gender: "male", Percentage: 31.8},
+						{Gender: "female", Percentage: 30.6},
+					},
+					Ages: []*AgeRatio{
+						{Age: "unknown", Percentage: 37.6},
+						{Age: "from50", Percentage: 17.3},
+					},
+					Areas: []*AreaRatio{
+						{Area: "unknown", Percentage: 42.9},
+						{Area: "徳島", Percentage: 2.9},
+					},
+					AppTypes: []*AppTypeRatio{
+						{AppType: "ios", Percentage: 62.4},
+						{AppType: "android", Percentage: 27.7},
+						{AppType: "others", Percentage: 9.9},
+					},
+					SubscriptionPeriods: []*SubscriptionPeriodRatio{
+						{SubscriptionPeriod: "over365days", Percentage: 96.4},
+						{SubscriptionPeriod: "within365days", Percentage: 1.9},
+						{SubscriptionPeriod: "within7days", Percentage: 1.7},
+					},
+				},
+			},
+		},
+		{
+			// Too few friends to calculate the demographics
+			ResponseCode: 200,
+			Response:     []byte(`{"available":false}`),
+			Want: want{
+				Response: &FriendDemographicsResponse{Available: false},
+			},
+		},
+		{
+			// Internal server error
+			ResponseCode: 500,
+			Response:     []byte(`{"message":"An error occurred"}`),
+			Want: want{
+				Error: &APIError{
+					Code: 500,
+					Response: &ErrorResponse{
+						Message: "An error occurred",
+					},
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != APIEndpointGetFriendDemographics {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointGetFriendDemographics)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := client.GetFriendDemographics().Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestGetNumberOfMessageDeliveries(t *testing.T) {
+	type want struct {
+		Date     string
+		Response *MessagesNumberDeliveryResponse
+		Error    error
+	}
+	var testCases = []struct {
+		Date         string
+		ResponseCode int
+		Response     []byte
+		Want         want
+	}{
+		{
+			Date:         "20190418",
+			ResponseCode: 200,
+			Response: []byte(`{
+				"status": "ready",
+				"broadcast": 5385,
+				"targeting": 522,
+				"autoResponse": 12,
+				"welcomeResponse": 7,
+				"chat": 3,
+				"apiBroadcast": 1000,
+				"apiPush": 1200,
+				"apiMulticast": 1300,
+				"apiReply": 1400
+			}`),
+			Want: want{
+				Date: "20190418",
+				Response: &MessagesNumberDeliveryResponse{
+					Status:          "ready",
+					Broadcast:       5385,
+					Targeting:       522,
+					AutoResponse:    12,
+					WelcomeResponse: 7,
+					Chat:            3,
+					APIBroadcast:    1000,
+					APIPush:         1200,
+					APIMulticast:    1300,
+					APIReply:        1400,
+				},
+			},
+		},
+		{
+			Date:         "20190419",
+			ResponseCode: 200,
+			Response:     []byte(`{"status":"unready"}`),
+			Want: want{
+				Date:     "20190419",
+				Response: &MessagesNumberDeliveryResponse{Status: "unready"},
+			},
+		},
+		{
+			// Bad request
+			Date:         "2019-04-18",
+			ResponseCode: 400,
+			Response:     []byte(`{"message":"Invalid date"}`),
+			Want: want{
+				Date: "2019-04-18",
+				Error: &APIError{
+					Code: 400,
+					Response: &ErrorResponse{
+						Message: "Invalid date",
+					},
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != APIEndpointGetNumberOfMessageDeliveries {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointGetNumberOfMessageDeliveries)
+		}
+		if date := r.URL.Query().Get("date"); date != tc.Want.Date {
+			t.Errorf("date %s; want %s", date, tc.Want.Date)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := client.GetNumberOfMessageDeliveries(tc.Date).Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestGetNumberOfFollowers(t *testing.T) {
+	type want struct {
+		Date     string
+		Response *MessagesNumberFollowersResponse
+		Error    error
+	}
+	var testCases = []struct {
+		Date         string
+		ResponseCode int
+		Response     []byte
+		Want         want
+	}{
+		{
+			Date:         "20191105",
+			ResponseCode: 200,
+			Response:     []byte(`{"status":"ready","followers":7620,"targetedReaches":5657,"blocks":290}`),
+			Want: want{
+				Date: "20191105",
+				Response: &MessagesNumberFollowersResponse{
+					Status:          "ready",
+					Followers:       7620,
+					TargetedReaches: 5657,
+					Blocks:          290,
+				},
+			},
+		},
+		{
+			Date:         "20191106",
+			ResponseCode: 200,
+			Response:     []byte(`{"status":"out_of_service"}`),
+			Want: want{
+				Date:     "20191106",
+				Response: &MessagesNumberFollowersResponse{Status: "out_of_service"},
+			},
+		},
+		{
+			// Bad request
+			Date:         "2019-11-05",
+			ResponseCode: 400,
+			Response:     []byte(`{"message":"Invalid date"}`),
+			Want: want{
+				Date: "2019-11-05",
+				Error: &APIError{
+					Code: 400,
+					Response: &ErrorResponse{
+						Message: "Invalid date",
+					},
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != APIEndpointGetNumberOfFollowers {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointGetNumberOfFollowers)
+		}
+		if date := r.URL.Query().Get("date"); date != tc.Want.Date {
+			t.Errorf("date %s; want %s", date, tc.Want.Date)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := client.GetNumberOfFollowers(tc.Date).Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestGetUserInteractionStatistics(t *testing.T) {
+	const requestID = "f70dd685-499a-4231-a441-f24b8d4fba21"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != APIEndpointGetUserInteractionStatistics {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointGetUserInteractionStatistics)
+		}
+		if got := r.URL.Query().Get("requestId"); got != requestID {
+			t.Errorf("requestId %s; want %s", got, requestID)
+		}
+		w.Write([]byte(`{
+			"overview": {
+				"requestId": "f70dd685-499a-4231-a441-f24b8d4fba21",
+				"timestamp": 1568214000,
+				"delivered": 32,
+				"uniqueImpression": 4,
+				"uniqueClick": null,
+				"uniqueMediaPlayed": 2,
+				"uniqueMediaPlayed100Percent": -1
+			},
+			"messages": [
+				{
+					"seq": 1,
+					"impression": 18,
+					"mediaPlayed": 11,
+					"mediaPlayed25Percent": -1,
+					"mediaPlayed50Percent": -1,
+					"mediaPlayed75Percent": -1,
+					"mediaPlayed100Percent": -1,
+					"uniqueMediaPlayed": 2,
+					"uniqueMediaPlayed25Percent": -1,
+					"uniqueMediaPlayed50Percent": -1,
+					"uniqueMediaPlayed75Percent": -1,
+					"uniqueMediaPlayed100Percent": -1
+				},
+				{
+					"seq": 2,
+					"impression": 12,
+					"mediaPlayed": null,
+					"uniqueMediaPlayed": null
+				}
+			],
+			"clicks": [
+				{
+					"seq": 1,
+					"url": "https://www.yahoo.co.jp/",
+					"click": -1,
+					"uniqueClick": -1,
+					"uniqueClickOfRequest": -1
+				},
+				{
+					"seq": 2,
+					"url": "https://line.me/",
+					"click": 25,
+					"uniqueClick": 12,
+					"uniqueClickOfRequest": 12
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetUserInteractionStatistics(requestID).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &UserInteractionStatisticsResponse{
+		Overview: OverviewStatistics{
+			RequestID:                   requestID,
+			Timestamp:                   1568214000,
+			Delivered:                   32,
+			UniqueImpression:            4,
+			UniqueMediaPlayed:           2,
+			UniqueMediaPlayed100Percent: -1,
+		},
+		Messages: []MessageStatistics{
+			{
+				Seq:                         1,
+				Impression:                  18,
+				MediaPlayed:                 11,
+				MediaPlayed25Percent:        -1,
+				MediaPlayed50Percent:        -1,
+				MediaPlayed75Percent:        -1,
+				MediaPlayed100Percent:       -1,
+				UniqueMediaPlayed:           2,
+				UniqueMediaPlayed25Percent:  -1,
+				UniqueMediaPlayed50Percent:  -1,
+				UniqueMediaPlayed75Percent:  -1,
+				UniqueMediaPlayed100Percent: -1,
+			},
+			{
+				Seq:        2,
+				Impression: 12,
+			},
+		},
+		Clicks: []ClickStatistics{
+			{
+				Seq:                  1,
+				URL:                  "https://www.yahoo.co.jp/",
+				Click:                -1,
+				UniqueClick:          -1,
+				UniqueClickOfRequest: -1,
+			},
+			{
+				Seq:                  2,
+				URL:                  "https://line.me/",
+				Click:                25,
+				UniqueClick:          12,
+				UniqueClickOfRequest: 12,
+			},
+		},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Response %v; want %v", res, want)
+	}
+}
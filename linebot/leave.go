@@ -52,7 +52,7 @@ func (call *LeaveGroupCall) Do() (*BasicResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	return decodeToBasicResponse(res)
+	return decodeToBasicResponse(res, call.c.codec)
 }
 
 // LeaveRoom method
@@ -87,5 +87,5 @@ func (call *LeaveRoomCall) Do() (*BasicResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	return decodeToBasicResponse(res)
+	return decodeToBasicResponse(res, call.c.codec)
 }
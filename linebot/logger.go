@@ -0,0 +1,84 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Logger type
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger function
+// Once set, the client logs the method, URL, JSON body, status code and
+// request ID of each API call.
+func WithLogger(l Logger) ClientOption {
+	return func(client *Client) error {
+		client.logger = l
+		return nil
+	}
+}
+
+// WithRedactedLogs function
+// It replaces user, group and room IDs and reply tokens in logged URLs and
+// bodies with a short hash, so that logs can still be correlated.
+func WithRedactedLogs() ClientOption {
+	return func(client *Client) error {
+		client.redactLogs = true
+		return nil
+	}
+}
+
+var (
+	redactIDPattern         = regexp.MustCompile(`\b[UCR][0-9a-f]{32}\b`)
+	redactReplyTokenPattern = regexp.MustCompile(`("replyToken"\s*:\s*")([^"]*)(")`)
+)
+
+func redact(s string) string {
+	s = redactIDPattern.ReplaceAllStringFunc(s, redactedHash)
+	return redactReplyTokenPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := redactReplyTokenPattern.FindStringSubmatch(m)
+		return sub[1] + redactedHash(sub[2]) + sub[3]
+	})
+}
+
+func redactedHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "redacted:" + hex.EncodeToString(sum[:4])
+}
+
+func (client *Client) logRequest(req *http.Request, reqBody []byte, res *http.Response, err error) {
+	u := req.URL.String()
+	body := strings.TrimSpace(string(reqBody))
+	if client.redactLogs {
+		u = redact(u)
+		body = redact(body)
+	}
+	if err != nil {
+		client.logger.Printf("linebot: %s %s %s: %v", req.Method, u, body, err)
+		return
+	}
+	if requestID := res.Header.Get("X-Line-Request-Id"); requestID != "" {
+		client.logger.Printf("linebot: %s %s %s: %d (requestId=%s)", req.Method, u, body, res.StatusCode, requestID)
+		return
+	}
+	client.logger.Printf("linebot: %s %s %s: %d", req.Method, u, body, res.StatusCode)
+}
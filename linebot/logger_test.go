@@ -0,0 +1,119 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestLogger(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Header().Set("X-Line-Request-Id", "f70dd685-499a-4231-a441-f24b8d4fba21")
+		if r.URL.Path == APIEndpointReplyMessage {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"Invalid reply token"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := &testLogger{}
+	if err := WithLogger(logger)(client); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).Do(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ReplyMessage("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", NewTextMessage("Hello, world")).Do(); err == nil {
+		t.Fatal("reply succeeded; want an error")
+	}
+	want := []string{
+		fmt.Sprintf(`linebot: POST %s/v2/bot/message/push {"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"Hello, world"}]}: 200 (requestId=f70dd685-499a-4231-a441-f24b8d4fba21)`, server.URL),
+		fmt.Sprintf(`linebot: POST %s/v2/bot/message/reply {"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"text","text":"Hello, world"}]}: 400 (requestId=f70dd685-499a-4231-a441-f24b8d4fba21)`, server.URL),
+	}
+	if len(logger.lines) != len(want) {
+		t.Fatalf("logged %q; want %q", logger.lines, want)
+	}
+	for i := range want {
+		if logger.lines[i] != want[i] {
+			t.Errorf("log %d %q; want %q", i, logger.lines[i], want[i])
+		}
+	}
+}
+
+func TestRedactedLogs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	userID := "U0047556f2e40dba2456887320ba7c76d"
+	for _, redacted := range []bool{false, true} {
+		client, err := mockClient(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger := &testLogger{}
+		options := []ClientOption{WithLogger(logger)}
+		if redacted {
+			options = append(options, WithRedactedLogs())
+		}
+		for _, option := range options {
+			if err := option(client); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := client.PushMessage(userID, NewTextMessage("Hello, world")).Do(); err != nil {
+			t.Fatal(err)
+		}
+		if len(logger.lines) != 1 {
+			t.Fatalf("logged %d lines; want 1", len(logger.lines))
+		}
+		line := logger.lines[0]
+		if !strings.Contains(line, APIEndpointPushMessage) {
+			t.Errorf("log %q does not contain the endpoint", line)
+		}
+		if got := strings.Contains(line, userID); got == redacted {
+			t.Errorf("redacted %v: log %q contains the user ID: %v", redacted, line, got)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	got := redact(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","to":"U0047556f2e40dba2456887320ba7c76d"}`)
+	if strings.Contains(got, "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA") || strings.Contains(got, "U0047556f2e40dba2456887320ba7c76d") {
+		t.Errorf("redact %q; want the reply token and the user ID removed", got)
+	}
+	if got != redact(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","to":"U0047556f2e40dba2456887320ba7c76d"}`) {
+		t.Errorf("redact is not deterministic")
+	}
+}
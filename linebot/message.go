@@ -16,6 +16,13 @@ package linebot
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // MessageType type
@@ -27,12 +34,17 @@ const (
 	MessageTypeImage    MessageType = "image"
 	MessageTypeVideo    MessageType = "video"
 	MessageTypeAudio    MessageType = "audio"
+	MessageTypeFile     MessageType = "file"
 	MessageTypeLocation MessageType = "location"
 	MessageTypeSticker  MessageType = "sticker"
 	MessageTypeTemplate MessageType = "template"
 	MessageTypeImagemap MessageType = "imagemap"
+	MessageTypeFlex     MessageType = "flex"
 )
 
+// maxTextLength is the maximum number of characters in a text message
+const maxTextLength = 5000
+
 // Message inteface
 type Message interface {
 	json.Marshaler
@@ -41,26 +53,76 @@ type Message interface {
 
 // TextMessage type
 type TextMessage struct {
-	ID   string
-	Text string
+	ID         string
+	Text       string
+	Emojis     []*Emoji
+	QuickReply *QuickReply
+}
+
+// Emoji type
+// Index is the position of the `$` placeholder in the text, counted in characters.
+type Emoji struct {
+	Index     int    `json:"index"`
+	ProductID string `json:"productId"`
+	EmojiID   string `json:"emojiId"`
 }
 
 // MarshalJSON method of TextMessage
 func (m *TextMessage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Type MessageType `json:"type"`
-		Text string      `json:"text"`
+		Type       MessageType `json:"type"`
+		Text       string      `json:"text"`
+		Emojis     []*Emoji    `json:"emojis,omitempty"`
+		QuickReply *QuickReply `json:"quickReply,omitempty"`
 	}{
-		Type: MessageTypeText,
-		Text: m.Text,
+		Type:       MessageTypeText,
+		Text:       m.Text,
+		Emojis:     m.Emojis,
+		QuickReply: m.QuickReply,
 	})
 }
 
+// AddEmoji method of TextMessage
+func (m *TextMessage) AddEmoji(emoji *Emoji) *TextMessage {
+	m.Emojis = append(m.Emojis, emoji)
+	return m
+}
+
+// Validate method of TextMessage
+// If the text is longer than 5000 characters, the returned *ValidationError
+// has ErrTextTooLong as `Err`.
+func (m *TextMessage) Validate() error {
+	length := utf8.RuneCountInString(m.Text)
+	if length > maxTextLength {
+		return &ValidationError{
+			Field:  "text",
+			Reason: fmt.Sprintf("must be at most %d characters", maxTextLength),
+			Err:    ErrTextTooLong,
+		}
+	}
+	for i, emoji := range m.Emojis {
+		if emoji == nil {
+			return &ValidationError{
+				Field:  fmt.Sprintf("emojis[%d]", i),
+				Reason: "must not be nil",
+			}
+		}
+		if emoji.Index < 0 || emoji.Index >= length {
+			return &ValidationError{
+				Field:  fmt.Sprintf("emojis[%d].index", i),
+				Reason: fmt.Sprintf("must be within the text length %d", length),
+			}
+		}
+	}
+	return nil
+}
+
 // ImageMessage type
 type ImageMessage struct {
 	ID                 string
 	OriginalContentURL string
 	PreviewImageURL    string
+	QuickReply         *QuickReply
 }
 
 // MarshalJSON method of ImageMessage
@@ -69,10 +131,12 @@ func (m *ImageMessage) MarshalJSON() ([]byte, error) {
 		Type               MessageType `json:"type"`
 		OriginalContentURL string      `json:"originalContentUrl"`
 		PreviewImageURL    string      `json:"previewImageUrl"`
+		QuickReply         *QuickReply `json:"quickReply,omitempty"`
 	}{
 		Type:               MessageTypeImage,
 		OriginalContentURL: m.OriginalContentURL,
 		PreviewImageURL:    m.PreviewImageURL,
+		QuickReply:         m.QuickReply,
 	})
 }
 
@@ -81,6 +145,14 @@ type VideoMessage struct {
 	ID                 string
 	OriginalContentURL string
 	PreviewImageURL    string
+	Duration           int
+	QuickReply         *QuickReply
+}
+
+// DurationValue method of VideoMessage
+// Duration is only set on incoming video messages.
+func (m *VideoMessage) DurationValue() time.Duration {
+	return time.Duration(m.Duration) * time.Millisecond
 }
 
 // MarshalJSON method of VideoMessage
@@ -89,10 +161,12 @@ func (m *VideoMessage) MarshalJSON() ([]byte, error) {
 		Type               MessageType `json:"type"`
 		OriginalContentURL string      `json:"originalContentUrl"`
 		PreviewImageURL    string      `json:"previewImageUrl"`
+		QuickReply         *QuickReply `json:"quickReply,omitempty"`
 	}{
 		Type:               MessageTypeVideo,
 		OriginalContentURL: m.OriginalContentURL,
 		PreviewImageURL:    m.PreviewImageURL,
+		QuickReply:         m.QuickReply,
 	})
 }
 
@@ -101,6 +175,12 @@ type AudioMessage struct {
 	ID                 string
 	OriginalContentURL string
 	Duration           int
+	QuickReply         *QuickReply
+}
+
+// DurationValue method of AudioMessage
+func (m *AudioMessage) DurationValue() time.Duration {
+	return time.Duration(m.Duration) * time.Millisecond
 }
 
 // MarshalJSON method of AudioMessage
@@ -109,112 +189,261 @@ func (m *AudioMessage) MarshalJSON() ([]byte, error) {
 		Type               MessageType `json:"type"`
 		OriginalContentURL string      `json:"originalContentUrl"`
 		Duration           int         `json:"duration"`
+		QuickReply         *QuickReply `json:"quickReply,omitempty"`
 	}{
 		Type:               MessageTypeAudio,
 		OriginalContentURL: m.OriginalContentURL,
 		Duration:           m.Duration,
+		QuickReply:         m.QuickReply,
+	})
+}
+
+// FileMessage type
+// Files can only be received from users.
+type FileMessage struct {
+	ID       string
+	FileName string
+	FileSize int
+}
+
+// MarshalJSON method of FileMessage
+func (m *FileMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type     MessageType `json:"type"`
+		ID       string      `json:"id"`
+		FileName string      `json:"fileName"`
+		FileSize int         `json:"fileSize"`
+	}{
+		Type:     MessageTypeFile,
+		ID:       m.ID,
+		FileName: m.FileName,
+		FileSize: m.FileSize,
 	})
 }
 
 // LocationMessage type
 type LocationMessage struct {
-	ID        string
-	Title     string
-	Address   string
-	Latitude  float64
-	Longitude float64
+	ID         string
+	Title      string
+	Address    string
+	Latitude   float64
+	Longitude  float64
+	QuickReply *QuickReply
 }
 
 // MarshalJSON method of LocationMessage
 func (m *LocationMessage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Type      MessageType `json:"type"`
-		Title     string      `json:"title"`
-		Address   string      `json:"address"`
-		Latitude  float64     `json:"latitude"`
-		Longitude float64     `json:"longitude"`
+		Type       MessageType `json:"type"`
+		Title      string      `json:"title"`
+		Address    string      `json:"address"`
+		Latitude   float64     `json:"latitude"`
+		Longitude  float64     `json:"longitude"`
+		QuickReply *QuickReply `json:"quickReply,omitempty"`
 	}{
-		Type:      MessageTypeLocation,
-		Title:     m.Title,
-		Address:   m.Address,
-		Latitude:  m.Latitude,
-		Longitude: m.Longitude,
+		Type:       MessageTypeLocation,
+		Title:      m.Title,
+		Address:    m.Address,
+		Latitude:   m.Latitude,
+		Longitude:  m.Longitude,
+		QuickReply: m.QuickReply,
 	})
 }
 
 // StickerMessage type
 type StickerMessage struct {
-	ID        string
-	PackageID string
-	StickerID string
+	ID                  string
+	PackageID           string
+	StickerID           string
+	StickerResourceType StickerResourceType
+	Keywords            []string
+	QuickReply          *QuickReply
 }
 
+// StickerResourceType type
+type StickerResourceType string
+
+// StickerResourceType constants
+const (
+	StickerResourceTypeStatic         StickerResourceType = "STATIC"
+	StickerResourceTypeAnimation      StickerResourceType = "ANIMATION"
+	StickerResourceTypeSound          StickerResourceType = "SOUND"
+	StickerResourceTypeAnimationSound StickerResourceType = "ANIMATION_SOUND"
+	StickerResourceTypePopup          StickerResourceType = "POPUP"
+	StickerResourceTypePopupSound     StickerResourceType = "POPUP_SOUND"
+	StickerResourceTypeNameText       StickerResourceType = "NAME_TEXT"
+	StickerResourceTypePerStickerText StickerResourceType = "PER_STICKER_TEXT"
+)
+
 // MarshalJSON method of StickerMessage
 func (m *StickerMessage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Type      MessageType `json:"type"`
-		PackageID string      `json:"packageId"`
-		StickerID string      `json:"stickerId"`
+		Type       MessageType `json:"type"`
+		PackageID  string      `json:"packageId"`
+		StickerID  string      `json:"stickerId"`
+		QuickReply *QuickReply `json:"quickReply,omitempty"`
 	}{
-		Type:      MessageTypeSticker,
-		PackageID: m.PackageID,
-		StickerID: m.StickerID,
+		Type:       MessageTypeSticker,
+		PackageID:  m.PackageID,
+		StickerID:  m.StickerID,
+		QuickReply: m.QuickReply,
 	})
 }
 
 // TemplateMessage type
 type TemplateMessage struct {
-	AltText  string
-	Template Template
+	AltText    string
+	Template   Template
+	QuickReply *QuickReply
 }
 
 // MarshalJSON method of TemplateMessage
 func (m *TemplateMessage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Type     MessageType `json:"type"`
-		AltText  string      `json:"altText"`
-		Template Template    `json:"template"`
+		Type       MessageType `json:"type"`
+		AltText    string      `json:"altText"`
+		Template   Template    `json:"template"`
+		QuickReply *QuickReply `json:"quickReply,omitempty"`
 	}{
-		Type:     MessageTypeTemplate,
-		AltText:  m.AltText,
-		Template: m.Template,
+		Type:       MessageTypeTemplate,
+		AltText:    m.AltText,
+		Template:   m.Template,
+		QuickReply: m.QuickReply,
 	})
 }
 
+// Validate method of TemplateMessage
+func (m *TemplateMessage) Validate() error {
+	switch t := m.Template.(type) {
+	case *ButtonsTemplate:
+		return validateActions("template.actions", t.Actions)
+	case *ConfirmTemplate:
+		return validateActions("template.actions", t.Actions)
+	case *CarouselTemplate:
+		for i, column := range t.Columns {
+			if err := validateActions(fmt.Sprintf("template.columns[%d].actions", i), column.Actions); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // ImagemapMessage type
 type ImagemapMessage struct {
-	BaseURL  string
-	AltText  string
-	BaseSize ImagemapBaseSize
-	Actions  []ImagemapAction
+	BaseURL    string
+	AltText    string
+	BaseSize   ImagemapBaseSize
+	Actions    []ImagemapAction
+	QuickReply *QuickReply
 }
 
 // MarshalJSON method of ImagemapMessage
 func (m *ImagemapMessage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Type     MessageType      `json:"type"`
-		BaseURL  string           `json:"baseUrl"`
-		AltText  string           `json:"altText"`
-		BaseSize ImagemapBaseSize `json:"baseSize"`
-		Actions  []ImagemapAction `json:"actions"`
+		Type       MessageType      `json:"type"`
+		BaseURL    string           `json:"baseUrl"`
+		AltText    string           `json:"altText"`
+		BaseSize   ImagemapBaseSize `json:"baseSize"`
+		Actions    []ImagemapAction `json:"actions"`
+		QuickReply *QuickReply      `json:"quickReply,omitempty"`
 	}{
-		Type:     MessageTypeImagemap,
-		BaseURL:  m.BaseURL,
-		AltText:  m.AltText,
-		BaseSize: m.BaseSize,
-		Actions:  m.Actions,
+		Type:       MessageTypeImagemap,
+		BaseURL:    m.BaseURL,
+		AltText:    m.AltText,
+		BaseSize:   m.BaseSize,
+		Actions:    m.Actions,
+		QuickReply: m.QuickReply,
 	})
 }
 
+// FlexMessage type
+type FlexMessage struct {
+	AltText    string
+	Contents   FlexContainer
+	QuickReply *QuickReply
+}
+
+// MarshalJSON method of FlexMessage
+func (m *FlexMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type       MessageType   `json:"type"`
+		AltText    string        `json:"altText"`
+		Contents   FlexContainer `json:"contents"`
+		QuickReply *QuickReply   `json:"quickReply,omitempty"`
+	}{
+		Type:       MessageTypeFlex,
+		AltText:    m.AltText,
+		Contents:   m.Contents,
+		QuickReply: m.QuickReply,
+	})
+}
+
+// WithQuickReply method of TextMessage
+func (m *TextMessage) WithQuickReply(quickReply *QuickReply) *TextMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of ImageMessage
+func (m *ImageMessage) WithQuickReply(quickReply *QuickReply) *ImageMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of VideoMessage
+func (m *VideoMessage) WithQuickReply(quickReply *QuickReply) *VideoMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of AudioMessage
+func (m *AudioMessage) WithQuickReply(quickReply *QuickReply) *AudioMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of LocationMessage
+func (m *LocationMessage) WithQuickReply(quickReply *QuickReply) *LocationMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of StickerMessage
+func (m *StickerMessage) WithQuickReply(quickReply *QuickReply) *StickerMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of TemplateMessage
+func (m *TemplateMessage) WithQuickReply(quickReply *QuickReply) *TemplateMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of ImagemapMessage
+func (m *ImagemapMessage) WithQuickReply(quickReply *QuickReply) *ImagemapMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
+// WithQuickReply method of FlexMessage
+func (m *FlexMessage) WithQuickReply(quickReply *QuickReply) *FlexMessage {
+	m.QuickReply = quickReply
+	return m
+}
+
 // implements Message interface
 func (*TextMessage) message()     {}
 func (*ImageMessage) message()    {}
 func (*VideoMessage) message()    {}
 func (*AudioMessage) message()    {}
+func (*FileMessage) message()     {}
 func (*LocationMessage) message() {}
 func (*StickerMessage) message()  {}
 func (*TemplateMessage) message() {}
 func (*ImagemapMessage) message() {}
+func (*FlexMessage) message()     {}
 
 // NewTextMessage function
 func NewTextMessage(content string) *TextMessage {
@@ -223,6 +452,15 @@ func NewTextMessage(content string) *TextMessage {
 	}
 }
 
+// NewEmoji function
+func NewEmoji(index int, productID, emojiID string) *Emoji {
+	return &Emoji{
+		Index:     index,
+		ProductID: productID,
+		EmojiID:   emojiID,
+	}
+}
+
 // NewImageMessage function
 func NewImageMessage(originalContentURL, previewImageURL string) *ImageMessage {
 	return &ImageMessage{
@@ -257,6 +495,29 @@ func NewLocationMessage(title, address string, latitude, longitude float64) *Loc
 	}
 }
 
+// NewLocationMessageFromLatLng function
+// `latlng` is a latitude and a longitude separated by a comma, e.g. "35.65910807942215,139.70372892916203".
+func NewLocationMessageFromLatLng(title, address, latlng string) (*LocationMessage, error) {
+	invalid := &ValidationError{
+		Field:  "latlng",
+		Reason: fmt.Sprintf("%q is not in the form of \"latitude,longitude\"", latlng),
+	}
+	parts := strings.Split(latlng, ",")
+	if len(parts) != 2 {
+		return nil, invalid
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	// NaN is not caught by the range check
+	if err != nil || math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return nil, invalid
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return nil, invalid
+	}
+	return NewLocationMessage(title, address, latitude, longitude), nil
+}
+
 // NewStickerMessage function
 func NewStickerMessage(packageID, stickerID string) *StickerMessage {
 	return &StickerMessage{
@@ -265,6 +526,14 @@ func NewStickerMessage(packageID, stickerID string) *StickerMessage {
 	}
 }
 
+// NewFlexMessage function
+func NewFlexMessage(altText string, contents FlexContainer) *FlexMessage {
+	return &FlexMessage{
+		AltText:  altText,
+		Contents: contents,
+	}
+}
+
 // NewTemplateMessage function
 func NewTemplateMessage(altText string, template Template) *TemplateMessage {
 	return &TemplateMessage{
@@ -282,3 +551,40 @@ func NewImagemapMessage(baseURL, altText string, baseSize ImagemapBaseSize, acti
 		Actions:  actions,
 	}
 }
+
+// DebugJSON function
+// It returns the messages as indented JSON for logging and snapshot tests.
+func DebugJSON(messages []Message) string {
+	b, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("linebot: %v", err)
+	}
+	return string(b)
+}
+
+// WriteNDJSON function
+// It writes each message as a single line of JSON for structured log ingestion.
+func WriteNDJSON(w io.Writer, messages []Message) error {
+	enc := json.NewEncoder(w)
+	for _, m := range messages {
+		if err := enc.Encode(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalMessages function
+// It returns the messages as `{"messages":[...]}` in the same way as the body
+// of a push or reply, for snapshot tests of code building messages.
+// The messages are validated as they are before they are sent.
+func MarshalMessages(messages []Message) ([]byte, error) {
+	if err := validateMessages(messages); err != nil {
+		return nil, err
+	}
+	return json.Marshal(&struct {
+		Messages []Message `json:"messages"`
+	}{
+		Messages: messages,
+	})
+}
@@ -0,0 +1,233 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDebugJSON(t *testing.T) {
+	got := DebugJSON([]Message{
+		NewTextMessage("Hello, world"),
+		NewStickerMessage("1", "1"),
+	})
+	want := `[
+  {
+    "type": "text",
+    "text": "Hello, world"
+  },
+  {
+    "type": "sticker",
+    "packageId": "1",
+    "stickerId": "1"
+  }
+]`
+	if got != want {
+		t.Errorf("DebugJSON %s; want %s", got, want)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	messages := []Message{
+		NewTextMessage("Hello, world"),
+		NewStickerMessage("1", "1"),
+		NewImageMessage("http://example.com/original.jpg", "http://example.com/preview.jpg"),
+	}
+	var buf bytes.Buffer
+	if err := WriteNDJSON(&buf, messages); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(messages) {
+		t.Fatalf("lines %d; want %d", len(lines), len(messages))
+	}
+	want := []string{
+		`{"type":"text","text":"Hello, world"}`,
+		`{"type":"sticker","packageId":"1","stickerId":"1"}`,
+		`{"type":"image","originalContentUrl":"http://example.com/original.jpg","previewImageUrl":"http://example.com/preview.jpg"}`,
+	}
+	for i, line := range lines {
+		if line != want[i] {
+			t.Errorf("line %d %s; want %s", i, line, want[i])
+		}
+	}
+}
+
+func TestMarshalMessages(t *testing.T) {
+	var testCases = []struct {
+		Messages []Message
+		Want     string
+		Error    error
+	}{
+		{
+			Messages: []Message{NewTextMessage("Hello, world")},
+			Want:     `{"messages":[{"type":"text","text":"Hello, world"}]}`,
+		},
+		{
+			Messages: []Message{
+				NewTemplateMessage(
+					"this is a buttons template",
+					NewButtonsTemplate("", "Menu", "Please select", NewMessageTemplateAction("Say hello", "hello")),
+				),
+			},
+			Want: `{"messages":[{"type":"template","altText":"this is a buttons template","template":{"type":"buttons","title":"Menu","text":"Please select","actions":[{"type":"message","label":"Say hello","text":"hello"}]}}]}`,
+		},
+		{
+			Messages: []Message{
+				NewFlexMessage("Hello", &BubbleContainer{
+					Body: &BoxComponent{
+						Layout:   FlexBoxLayoutTypeVertical,
+						Contents: []FlexComponent{&TextComponent{Text: "Hello,"}, &TextComponent{Text: "World!"}},
+					},
+				}),
+				NewTextMessage("Thank you"),
+			},
+			Want: `{"messages":[{"type":"flex","altText":"Hello","contents":{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"text","text":"Hello,"},{"type":"text","text":"World!"}]}}},{"type":"text","text":"Thank you"}]}`,
+		},
+		{
+			Messages: []Message{NewTextMessage("Hello"), NewTextMessage(strings.Repeat("a", 5001))},
+			Error: &ValidationError{
+				Field:  "messages[1].text",
+				Reason: "must be at most 5000 characters",
+				Err:    ErrTextTooLong,
+			},
+		},
+	}
+	for i, tc := range testCases {
+		got, err := MarshalMessages(tc.Messages)
+		if !reflect.DeepEqual(err, tc.Error) {
+			t.Errorf("Error %d %v; want %v", i, err, tc.Error)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("MarshalMessages %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	audio := &AudioMessage{ID: "325708", Duration: 60000}
+	if got, want := audio.DurationValue(), time.Minute; got != want {
+		t.Errorf("AudioMessage.DurationValue %v; want %v", got, want)
+	}
+	video := &VideoMessage{ID: "325708", Duration: 1500}
+	if got, want := video.DurationValue(), 1500*time.Millisecond; got != want {
+		t.Errorf("VideoMessage.DurationValue %v; want %v", got, want)
+	}
+}
+
+func TestTextMessageEmojis(t *testing.T) {
+	message := NewTextMessage("$ LINE emoji $").
+		AddEmoji(NewEmoji(0, "5ac1bfd5040ab15980c9b435", "001")).
+		AddEmoji(NewEmoji(13, "5ac1bfd5040ab15980c9b435", "002"))
+	if err := message.Validate(); err != nil {
+		t.Errorf("Validate %v; want nil", err)
+	}
+	b, err := message.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"text","text":"$ LINE emoji $","emojis":[{"index":0,"productId":"5ac1bfd5040ab15980c9b435","emojiId":"001"},{"index":13,"productId":"5ac1bfd5040ab15980c9b435","emojiId":"002"}]}`
+	if string(b) != want {
+		t.Errorf("MarshalJSON %s; want %s", b, want)
+	}
+
+	message.AddEmoji(NewEmoji(14, "5ac1bfd5040ab15980c9b435", "003"))
+	err = message.Validate()
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Validate %v; want *ValidationError", err)
+	}
+	if verr.Field != "emojis[2].index" {
+		t.Errorf("Field %q; want %q", verr.Field, "emojis[2].index")
+	}
+
+	message = NewTextMessage("$ LINE emoji $").AddEmoji(nil)
+	wantErr := &ValidationError{Field: "emojis[0]", Reason: "must not be nil"}
+	if err := message.Validate(); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Validate %v; want %v", err, wantErr)
+	}
+}
+
+func TestTextMessageLength(t *testing.T) {
+	var testCases = []struct {
+		Text    string
+		TooLong bool
+	}{
+		{Text: strings.Repeat("a", 5000)},
+		{Text: strings.Repeat("a", 5001), TooLong: true},
+		// 5000 characters of 4 bytes
+		{Text: strings.Repeat("😀", 5000)},
+		{Text: strings.Repeat("😀", 5000) + "a", TooLong: true},
+	}
+	for i, tc := range testCases {
+		err := NewTextMessage(tc.Text).Validate()
+		if !tc.TooLong {
+			if err != nil {
+				t.Errorf("Validate %d %v; want nil", i, err)
+			}
+			continue
+		}
+		if verr, ok := err.(*ValidationError); !ok || verr.Field != "text" || verr.Err != ErrTextTooLong {
+			t.Errorf("Validate %d %v; want ErrTextTooLong of text", i, err)
+		}
+	}
+}
+
+func TestNewLocationMessageFromLatLng(t *testing.T) {
+	var testCases = []struct {
+		LatLng string
+		Want   *LocationMessage
+	}{
+		{
+			LatLng: "35.6,139.7",
+			Want:   NewLocationMessage("title", "address", 35.6, 139.7),
+		},
+		{
+			LatLng: " -33.8688, 151.2093 ",
+			Want:   NewLocationMessage("title", "address", -33.8688, 151.2093),
+		},
+		{LatLng: ""},
+		{LatLng: "35.6"},
+		{LatLng: "35.6,139.7,0"},
+		{LatLng: "35.6;139.7"},
+		{LatLng: "north,139.7"},
+		{LatLng: "35.6,"},
+		// Out of range
+		{LatLng: "91,139.7"},
+		{LatLng: "35.6,181"},
+		{LatLng: "NaN,NaN"},
+		{LatLng: "35.6,NaN"},
+		{LatLng: "Inf,139.7"},
+		{LatLng: "35.6,-Inf"},
+	}
+	for i, tc := range testCases {
+		got, err := NewLocationMessageFromLatLng("title", "address", tc.LatLng)
+		if tc.Want == nil {
+			verr, ok := err.(*ValidationError)
+			if !ok || verr.Field != "latlng" {
+				t.Errorf("Error %d %v; want *ValidationError for latlng", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("Error %d %v", i, err)
+		}
+		if !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("Message %d %v; want %v", i, got, tc.Want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MetricsFunc type
+// It is called after each API request with the logical operation name such as
+// "push", "reply" or "getProfile", the response status code (0 when no response
+// was received) and the elapsed time.
+type MetricsFunc func(operation string, statusCode int, elapsed time.Duration)
+
+// WithMetrics function
+func WithMetrics(f MetricsFunc) ClientOption {
+	return func(client *Client) error {
+		client.metrics = f
+		return nil
+	}
+}
+
+// OperationOther is the operation name reported for requests to unknown endpoints.
+const OperationOther = "other"
+
+// operations maps each endpoint to its operation name. More specific
+// endpoints precede the ones whose path parameters would also match them.
+var operations = []struct {
+	method    string
+	endpoint  string
+	operation string
+}{
+	{"POST", APIEndpointPushMessage, "push"},
+	{"POST", APIEndpointReplyMessage, "reply"},
+	{"POST", APIEndpointMulticast, "multicast"},
+	{"GET", APIEndpointGetMessageContent, "getMessageContent"},
+	{"POST", APIEndpointLeaveGroup, "leaveGroup"},
+	{"POST", APIEndpointLeaveRoom, "leaveRoom"},
+	{"GET", APIEndpointGetProfile, "getProfile"},
+	{"POST", APIEndpointCreateRichMenu, "createRichMenu"},
+	{"GET", APIEndpointListRichMenu, "getRichMenuList"},
+	{"GET", APIEndpointGetRichMenu, "getRichMenu"},
+	{"DELETE", APIEndpointDeleteRichMenu, "deleteRichMenu"},
+	{"POST", APIEndpointUploadRichMenuImage, "uploadRichMenuImage"},
+	{"POST", APIEndpointSetDefaultRichMenu, "setDefaultRichMenu"},
+	{"GET", APIEndpointDefaultRichMenu, "getDefaultRichMenu"},
+	{"DELETE", APIEndpointDefaultRichMenu, "cancelDefaultRichMenu"},
+	{"POST", APIEndpointLinkUserRichMenu, "linkUserRichMenu"},
+	{"DELETE", APIEndpointUnlinkUserRichMenu, "unlinkUserRichMenu"},
+	{"GET", APIEndpointGetNumberReplyMessages, "getNumberReplyMessages"},
+	{"GET", APIEndpointGetNumberPushMessages, "getNumberPushMessages"},
+	{"GET", APIEndpointGetNumberMulticastMessages, "getNumberMulticastMessages"},
+	{"GET", APIEndpointGetNumberBroadcastMessages, "getNumberBroadcastMessages"},
+	{"GET", APIEndpointGetMessageQuota, "getMessageQuota"},
+	{"GET", APIEndpointGetMessageQuotaConsumption, "getMessageQuotaConsumption"},
+	{"GET", APIEndpointGetBotInfo, "getBotInfo"},
+	{"PUT", APIEndpointWebhookEndpoint, "setWebhookEndpoint"},
+	{"GET", APIEndpointWebhookEndpoint, "getWebhookEndpoint"},
+	{"POST", APIEndpointTestWebhookEndpoint, "testWebhookEndpoint"},
+	{"POST", APIEndpointIssueLinkToken, "issueLinkToken"},
+	{"GET", APIEndpointGetFriendDemographics, "getFriendDemographics"},
+	{"GET", APIEndpointGetNumberOfMessageDeliveries, "getNumberOfMessageDeliveries"},
+	{"GET", APIEndpointGetNumberOfFollowers, "getNumberOfFollowers"},
+	{"GET", APIEndpointGetUserInteractionStatistics, "getUserInteractionStatistics"},
+	{"POST", APIEndpointCreateUploadAudienceGroup, "createUploadAudienceGroup"},
+	{"PUT", APIEndpointAddAudiences, "addAudiences"},
+	{"GET", APIEndpointAudienceGroupAuthorityLevel, "getAudienceGroupAuthorityLevel"},
+	{"PUT", APIEndpointAudienceGroupAuthorityLevel, "changeAudienceGroupAuthorityLevel"},
+	{"GET", APIEndpointGetAudienceGroups, "getAudienceGroups"},
+	{"GET", APIEndpointGetAudienceGroup, "getAudienceGroup"},
+	{"DELETE", APIEndpointDeleteAudienceGroup, "deleteAudienceGroup"},
+	{"PUT", APIEndpointUpdateAudienceGroupDescription, "updateAudienceGroupDescription"},
+}
+
+// operation returns the operation name of the request, so that metrics are
+// not labeled with the IDs contained in the path.
+func (client *Client) operation(req *http.Request) string {
+	p := strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(client.endpointBase.Path, "/"))
+	for _, op := range operations {
+		if op.method == req.Method && matchEndpoint(op.endpoint, p) {
+			return op.operation
+		}
+	}
+	return OperationOther
+}
+
+func matchEndpoint(endpoint, p string) bool {
+	want := strings.Split(endpoint, "/")
+	got := strings.Split(p, "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if want[i] == "%s" || want[i] == "%d" {
+			if got[i] == "" {
+				return false
+			}
+			continue
+		}
+		if want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
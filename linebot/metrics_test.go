@@ -0,0 +1,92 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Write([]byte(`{"userId":"U0047556f2e40dba2456887320ba7c76d","displayName":"BOT API"}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotOperation string
+	var gotStatusCode int
+	if err := WithMetrics(func(operation string, statusCode int, elapsed time.Duration) {
+		gotOperation = operation
+		gotStatusCode = statusCode
+	})(client); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetProfile("U0047556f2e40dba2456887320ba7c76d").Do(); err != nil {
+		t.Fatal(err)
+	}
+	if gotOperation != "getProfile" {
+		t.Errorf("operation %q; want %q", gotOperation, "getProfile")
+	}
+	if strings.Contains(gotOperation, "U0047556f2e40dba2456887320ba7c76d") {
+		t.Errorf("operation %q contains the user ID", gotOperation)
+	}
+	if gotStatusCode != http.StatusOK {
+		t.Errorf("statusCode %d; want %d", gotStatusCode, http.StatusOK)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	client, err := New("testsecret", "testtoken", WithEndpointBase("https://example.com/prefix/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testCases = []struct {
+		Method    string
+		Endpoint  string
+		Operation string
+	}{
+		{"POST", "/v2/bot/message/push", "push"},
+		{"POST", "/v2/bot/message/reply", "reply"},
+		{"GET", "/v2/bot/profile/U0047556f2e40dba2456887320ba7c76d", "getProfile"},
+		{"GET", "/v2/bot/richmenu/list", "getRichMenuList"},
+		{"GET", "/v2/bot/richmenu/richmenu-0000", "getRichMenu"},
+		{"DELETE", "/v2/bot/richmenu/richmenu-0000", "deleteRichMenu"},
+		{"POST", "/v2/bot/user/all/richmenu/richmenu-0000", "setDefaultRichMenu"},
+		{"POST", "/v2/bot/user/U0047556f2e40dba2456887320ba7c76d/richmenu/richmenu-0000", "linkUserRichMenu"},
+		{"DELETE", "/v2/bot/user/all/richmenu", "cancelDefaultRichMenu"},
+		{"DELETE", "/v2/bot/user/U0047556f2e40dba2456887320ba7c76d/richmenu", "unlinkUserRichMenu"},
+		{"GET", "/v2/bot/audienceGroup/4389303728991", "getAudienceGroup"},
+		{"GET", "/v2/bot/audienceGroup/authorityLevel", "getAudienceGroupAuthorityLevel"},
+		{"GET", "/v2/bot/audienceGroup/list", "getAudienceGroups"},
+		{"DELETE", "/v2/bot/audienceGroup/4389303728991", "deleteAudienceGroup"},
+		{"GET", "/v2/bot/unknown", OperationOther},
+	}
+	for i, tc := range testCases {
+		req, err := http.NewRequest(tc.Method, client.url(tc.Endpoint), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := client.operation(req); got != tc.Operation {
+			t.Errorf("%d: operation %q; want %q", i, got, tc.Operation)
+		}
+	}
+}
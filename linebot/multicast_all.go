@@ -0,0 +1,112 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// maxMulticastRecipients is the maximum number of user IDs in a multicast.
+const maxMulticastRecipients = 150
+
+// MulticastAll method
+// It sends `messages` to any number of users by splitting `to` into
+// multicasts of up to 150 user IDs.
+func (client *Client) MulticastAll(to []string, messages []Message) *MulticastAllCall {
+	return &MulticastAllCall{
+		c:           client,
+		to:          to,
+		messages:    messages,
+		concurrency: defaultConcurrency,
+	}
+}
+
+// MulticastAllCall type
+type MulticastAllCall struct {
+	c   *Client
+	ctx context.Context
+
+	to          []string
+	messages    []Message
+	concurrency int
+}
+
+// WithContext method
+func (call *MulticastAllCall) WithContext(ctx context.Context) *MulticastAllCall {
+	call.ctx = ctx
+	return call
+}
+
+// WithConcurrency method
+// It sets the number of multicasts sent at once, which is 10 by default.
+func (call *MulticastAllCall) WithConcurrency(concurrency int) *MulticastAllCall {
+	if concurrency > 0 {
+		call.concurrency = concurrency
+	}
+	return call
+}
+
+// Do method
+// Every multicast is sent even if some fail. The failures are returned as a
+// *MulticastError.
+func (call *MulticastAllCall) Do() error {
+	if err := validateMessages(call.messages); err != nil {
+		return err
+	}
+	var chunks [][]string
+	for i := 0; i < len(call.to); i += maxMulticastRecipients {
+		end := i + maxMulticastRecipients
+		if end > len(call.to) {
+			end = len(call.to)
+		}
+		chunks = append(chunks, call.to[i:end])
+	}
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, call.concurrency)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk []string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = call.multicast(chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+	var failures []*MulticastChunkError
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, &MulticastChunkError{To: chunks[i], Err: err})
+		}
+	}
+	if len(failures) > 0 {
+		return &MulticastError{Chunks: len(chunks), Failures: failures}
+	}
+	return nil
+}
+
+func (call *MulticastAllCall) multicast(to []string) error {
+	if err := call.c.acquire(call.ctx); err != nil {
+		return err
+	}
+	defer call.c.release()
+	_, err := call.c.Multicast(to, call.messages...).WithContext(call.ctx).Do()
+	return err
+}
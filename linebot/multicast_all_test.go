@@ -0,0 +1,85 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestMulticastAll(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.URL.Path != APIEndpointMulticast {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointMulticast)
+		}
+		body := struct {
+			To []string `json:"to"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		sizes = append(sizes, len(body.To))
+		mu.Unlock()
+		if body.To[0] == "Ublocked" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var to []string
+	for i := 0; i < 320; i++ {
+		to = append(to, fmt.Sprintf("U%03d", i))
+	}
+
+	if err := client.MulticastAll(to, []Message{NewTextMessage("Hello, world")}).Do(); err != nil {
+		t.Fatal(err)
+	}
+	sort.Ints(sizes)
+	if want := []int{20, 150, 150}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("recipients per multicast %v; want %v", sizes, want)
+	}
+
+	// The last chunk fails.
+	to = append(to[:300:300], "Ublocked")
+	err = client.MulticastAll(to, []Message{NewTextMessage("Hello, world")}).WithConcurrency(1).Do()
+	multicastErr, ok := err.(*MulticastError)
+	if !ok {
+		t.Fatalf("err %v; want *MulticastError", err)
+	}
+	if multicastErr.Chunks != 3 || len(multicastErr.Failures) != 1 {
+		t.Fatalf("MulticastError %d of %d; want 1 of 3", len(multicastErr.Failures), multicastErr.Chunks)
+	}
+	failure := multicastErr.Failures[0]
+	if want := []string{"Ublocked"}; !reflect.DeepEqual(failure.To, want) {
+		t.Errorf("To %v; want %v", failure.To, want)
+	}
+	if want := (&APIError{Code: 400, Response: &ErrorResponse{}}); !reflect.DeepEqual(failure.Err, want) {
+		t.Errorf("Err %v; want %v", failure.Err, want)
+	}
+}
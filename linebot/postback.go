@@ -0,0 +1,41 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/url"
+)
+
+// EncodePostbackData function
+// It encodes `values` in the URL query format sorted by key, e.g. "action=buy&itemId=123".
+func EncodePostbackData(values map[string]string) string {
+	v := url.Values{}
+	for key, value := range values {
+		v.Set(key, value)
+	}
+	return v.Encode()
+}
+
+// DecodePostbackData function
+// It decodes data encoded by EncodePostbackData. Only the first value is kept for
+// duplicated keys, and malformed pairs are skipped.
+func DecodePostbackData(data string) map[string]string {
+	v, _ := url.ParseQuery(data)
+	values := make(map[string]string, len(v))
+	for key := range v {
+		values[key] = v.Get(key)
+	}
+	return values
+}
@@ -0,0 +1,48 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPostbackData(t *testing.T) {
+	values := map[string]string{
+		"action": "buy",
+		"itemId": "123",
+		"note":   "50% off & free shipping = great!",
+		"name":   "すし",
+	}
+	data := EncodePostbackData(values)
+	want := "action=buy&itemId=123&name=%E3%81%99%E3%81%97&note=50%25+off+%26+free+shipping+%3D+great%21"
+	if data != want {
+		t.Errorf("EncodePostbackData %s; want %s", data, want)
+	}
+	if got := DecodePostbackData(data); !reflect.DeepEqual(got, values) {
+		t.Errorf("DecodePostbackData %v; want %v", got, values)
+	}
+}
+
+func TestDecodePostbackData(t *testing.T) {
+	got := DecodePostbackData("action=buy&itemId=123&itemId=456&bad=%zz")
+	want := map[string]string{
+		"action": "buy",
+		"itemId": "123",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodePostbackData %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// QuickReply type
+type QuickReply struct {
+	Items []*QuickReplyButton `json:"items"`
+}
+
+// Validate method of QuickReply
+func (r *QuickReply) Validate() error {
+	for i, item := range r.Items {
+		if item == nil {
+			continue
+		}
+		v, ok := item.Action.(validator)
+		if !ok {
+			continue
+		}
+		if err := v.Validate(); err != nil {
+			return prefixValidationError(fmt.Sprintf("items[%d].action", i), err)
+		}
+	}
+	return nil
+}
+
+// quickReplyOf returns the quick reply of `m`, or nil if it has none.
+func quickReplyOf(m Message) *QuickReply {
+	switch m := m.(type) {
+	case *TextMessage:
+		return m.QuickReply
+	case *ImageMessage:
+		return m.QuickReply
+	case *VideoMessage:
+		return m.QuickReply
+	case *AudioMessage:
+		return m.QuickReply
+	case *LocationMessage:
+		return m.QuickReply
+	case *StickerMessage:
+		return m.QuickReply
+	case *TemplateMessage:
+		return m.QuickReply
+	case *ImagemapMessage:
+		return m.QuickReply
+	case *FlexMessage:
+		return m.QuickReply
+	}
+	return nil
+}
+
+// QuickReplyButton type
+type QuickReplyButton struct {
+	ImageURL string
+	Action   TemplateAction
+}
+
+// MarshalJSON method of QuickReplyButton
+func (b *QuickReplyButton) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type     string         `json:"type"`
+		ImageURL string         `json:"imageUrl,omitempty"`
+		Action   TemplateAction `json:"action"`
+	}{
+		Type:     "action",
+		ImageURL: b.ImageURL,
+		Action:   b.Action,
+	})
+}
+
+// NewQuickReply function
+func NewQuickReply(buttons ...*QuickReplyButton) *QuickReply {
+	return &QuickReply{
+		Items: buttons,
+	}
+}
+
+// NewQuickReplyButton function
+// `imageURL` is optional. it can be empty.
+func NewQuickReplyButton(imageURL string, action TemplateAction) *QuickReplyButton {
+	return &QuickReplyButton{
+		ImageURL: imageURL,
+		Action:   action,
+	}
+}
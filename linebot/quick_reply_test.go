@@ -0,0 +1,92 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQuickReplyButton(t *testing.T) {
+	var testCases = []struct {
+		Button *QuickReplyButton
+		Want   string
+	}{
+		{
+			Button: NewQuickReplyButton("https://example.com/sushi.png", NewMessageTemplateAction("Sushi", "Sushi")),
+			Want:   `{"type":"action","imageUrl":"https://example.com/sushi.png","action":{"type":"message","label":"Sushi","text":"Sushi"}}`,
+		},
+		{
+			// Without image
+			Button: NewQuickReplyButton("", NewMessageTemplateAction("Tempura", "Tempura")),
+			Want:   `{"type":"action","action":{"type":"message","label":"Tempura","text":"Tempura"}}`,
+		},
+	}
+	for i, tc := range testCases {
+		got, err := json.Marshal(tc.Button)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("Button %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
+
+func TestMessageWithQuickReply(t *testing.T) {
+	quickReply := NewQuickReply(
+		NewQuickReplyButton("", NewMessageTemplateAction("Yes", "yes")),
+		NewQuickReplyButton("", NewMessageTemplateAction("No", "no")),
+	)
+	var testCases = []struct {
+		Message Message
+		Want    string
+	}{
+		{
+			Message: NewTextMessage("Hello, world").WithQuickReply(quickReply),
+			Want:    `{"type":"text","text":"Hello, world","quickReply":{"items":[{"type":"action","action":{"type":"message","label":"Yes","text":"yes"}},{"type":"action","action":{"type":"message","label":"No","text":"no"}}]}}`,
+		},
+		{
+			Message: NewStickerMessage("1", "1").WithQuickReply(quickReply),
+			Want:    `{"type":"sticker","packageId":"1","stickerId":"1","quickReply":{"items":[{"type":"action","action":{"type":"message","label":"Yes","text":"yes"}},{"type":"action","action":{"type":"message","label":"No","text":"no"}}]}}`,
+		},
+	}
+	for i, tc := range testCases {
+		got, err := json.Marshal(tc.Message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("Message %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
+
+func TestQuickReplyValidate(t *testing.T) {
+	message := NewTextMessage("Buy?").WithQuickReply(NewQuickReply(
+		NewQuickReplyButton("", NewMessageTemplateAction("No", "no")),
+		NewQuickReplyButton("", NewPostbackTemplateAction("Buy", strings.Repeat("a", 301), "")),
+	))
+	err := validateMessages([]Message{NewTextMessage("Hello, world"), message})
+	want := &ValidationError{
+		Field:  "messages[1].quickReply.items[1].action.data",
+		Reason: "must be at most 300 bytes",
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("validateMessages %v; want %v", err, want)
+	}
+}
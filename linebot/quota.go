@@ -0,0 +1,117 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"golang.org/x/net/context"
+)
+
+// MessageQuotaType type
+type MessageQuotaType string
+
+// MessageQuotaType constants
+const (
+	MessageQuotaTypeNone    MessageQuotaType = "none"
+	MessageQuotaTypeLimited MessageQuotaType = "limited"
+)
+
+// MessageQuotaStatus type
+// It combines the monthly message quota and how much of it has been consumed.
+type MessageQuotaStatus struct {
+	Type       MessageQuotaType
+	Value      int64
+	TotalUsage int64
+}
+
+// UsedPercentage method
+// It returns the consumed share of the quota in percent, e.g. 80 for alerting at 80%.
+// It always returns 0 when the quota is not limited.
+func (s *MessageQuotaStatus) UsedPercentage() float64 {
+	if s.Type != MessageQuotaTypeLimited || s.Value <= 0 {
+		return 0
+	}
+	return float64(s.TotalUsage) / float64(s.Value) * 100
+}
+
+// NewMessageQuotaStatus function
+func NewMessageQuotaStatus(quota *MessageQuotaResponse, consumption *MessageQuotaConsumptionResponse) *MessageQuotaStatus {
+	return &MessageQuotaStatus{
+		Type:       quota.Type,
+		Value:      quota.Value,
+		TotalUsage: consumption.TotalUsage,
+	}
+}
+
+// GetMessageQuota method
+func (client *Client) GetMessageQuota() *GetMessageQuotaCall {
+	return &GetMessageQuotaCall{
+		c: client,
+	}
+}
+
+// GetMessageQuotaCall type
+type GetMessageQuotaCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetMessageQuotaCall) WithContext(ctx context.Context) *GetMessageQuotaCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetMessageQuotaCall) Do() (*MessageQuotaResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointGetMessageQuota, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToMessageQuotaResponse(res, call.c.codec)
+}
+
+// GetMessageQuotaConsumption method
+func (client *Client) GetMessageQuotaConsumption() *GetMessageQuotaConsumptionCall {
+	return &GetMessageQuotaConsumptionCall{
+		c: client,
+	}
+}
+
+// GetMessageQuotaConsumptionCall type
+type GetMessageQuotaConsumptionCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetMessageQuotaConsumptionCall) WithContext(ctx context.Context) *GetMessageQuotaConsumptionCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetMessageQuotaConsumptionCall) Do() (*MessageQuotaConsumptionResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointGetMessageQuotaConsumption, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToMessageQuotaConsumptionResponse(res, call.c.codec)
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMessageQuotaStatusUsedPercentage(t *testing.T) {
+	var testCases = []struct {
+		Status *MessageQuotaStatus
+		Want   float64
+	}{
+		{
+			Status: &MessageQuotaStatus{Type: MessageQuotaTypeLimited, Value: 1000, TotalUsage: 800},
+			Want:   80,
+		},
+		{
+			Status: &MessageQuotaStatus{Type: MessageQuotaTypeLimited, Value: 15000, TotalUsage: 13500},
+			Want:   90,
+		},
+		{
+			Status: &MessageQuotaStatus{Type: MessageQuotaTypeLimited, Value: 1000, TotalUsage: 0},
+			Want:   0,
+		},
+		{
+			Status: &MessageQuotaStatus{Type: MessageQuotaTypeNone, TotalUsage: 500},
+			Want:   0,
+		},
+	}
+	for i, tc := range testCases {
+		if got := tc.Status.UsedPercentage(); got != tc.Want {
+			t.Errorf("UsedPercentage %d %v; want %v", i, got, tc.Want)
+		}
+	}
+}
+
+func TestGetMessageQuota(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		switch r.URL.Path {
+		case APIEndpointGetMessageQuota:
+			w.Write([]byte(`{"type":"limited","value":1000}`))
+		case APIEndpointGetMessageQuotaConsumption:
+			w.Write([]byte(`{"totalUsage":500}`))
+		default:
+			t.Errorf("URLPath %s; want %s or %s", r.URL.Path, APIEndpointGetMessageQuota, APIEndpointGetMessageQuotaConsumption)
+		}
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	quota, err := client.GetMessageQuota().Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQuota := &MessageQuotaResponse{Type: MessageQuotaTypeLimited, Value: 1000}
+	if !reflect.DeepEqual(quota, wantQuota) {
+		t.Errorf("Response %v; want %v", quota, wantQuota)
+	}
+	consumption, err := client.GetMessageQuotaConsumption().Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantConsumption := &MessageQuotaConsumptionResponse{TotalUsage: 500}
+	if !reflect.DeepEqual(consumption, wantConsumption) {
+		t.Errorf("Response %v; want %v", consumption, wantConsumption)
+	}
+	if got := NewMessageQuotaStatus(quota, consumption).UsedPercentage(); got != 50 {
+		t.Errorf("UsedPercentage %v; want %v", got, 50)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// WithRateLimit function
+// Once set, push, reply and multicast requests are spaced so that at most
+// `rps` requests are sent per second, including retries. A request waiting for
+// its turn returns the context's error when the context is done.
+func WithRateLimit(rps float64) ClientOption {
+	return func(client *Client) error {
+		if rps <= 0 {
+			return errors.New("rate limit must be positive")
+		}
+		client.limiter = &rateLimiter{
+			interval: time.Duration(float64(time.Second) / rps),
+			now:      time.Now,
+		}
+		return nil
+	}
+}
+
+// rateLimiter is a token bucket holding a single token, which is refilled
+// every `interval`.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	now      func() time.Time
+}
+
+// wait blocks until the next token is available or `ctx` is done.
+// The token is only taken once it is available, so that a caller giving up
+// doesn't delay the following ones.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		if ctx != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		l.mu.Lock()
+		now := l.now()
+		delay := l.next.Sub(now)
+		if delay <= 0 {
+			l.next = now.Add(l.interval)
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WithRateLimit(20)(client); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("hello")).Do(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the second and third requests wait for 50ms each
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("elapsed %v; want at least 100ms", elapsed)
+	}
+	if requests != 3 {
+		t.Errorf("requests %d; want 3", requests)
+	}
+}
+
+func TestWithRateLimitContext(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WithRateLimit(1)(client); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("hello")).Do(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("hello")).WithContext(ctx).Do()
+	if err != context.DeadlineExceeded {
+		t.Errorf("Error %v; want %v", err, context.DeadlineExceeded)
+	}
+	if requests != 1 {
+		t.Errorf("requests %d; want 1", requests)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := &rateLimiter{
+		interval: time.Second,
+		now:      func() time.Time { return now },
+	}
+	if err := limiter.wait(nil); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := limiter.wait(ctx)
+		cancel()
+		if err != context.DeadlineExceeded {
+			t.Errorf("Error %d %v; want %v", i, err, context.DeadlineExceeded)
+		}
+	}
+	// the cancelled calls don't take a token, so the next one is not delayed
+	now = now.Add(time.Second)
+	if err := limiter.wait(nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(time.Second); !limiter.next.Equal(want) {
+		t.Errorf("next %v; want %v", limiter.next, want)
+	}
+}
+
+func TestWithRateLimitInvalid(t *testing.T) {
+	if _, err := New("testsecret", "testtoken", WithRateLimit(0)); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"golang.org/x/net/context"
+)
+
+// NewReplyBatch method
+// The returned ReplyBatch uses a single reply per event source within a webhook batch.
+// Messages for further events from the same source are sent as push messages.
+func (client *Client) NewReplyBatch() *ReplyBatch {
+	return &ReplyBatch{
+		c:       client,
+		replied: map[string]bool{},
+	}
+}
+
+// ReplyBatch type
+type ReplyBatch struct {
+	c   *Client
+	ctx context.Context
+
+	replied map[string]bool
+	replies []*ReplyMessageCall
+	pushes  []*PushMessageCall
+}
+
+// WithContext method
+func (b *ReplyBatch) WithContext(ctx context.Context) *ReplyBatch {
+	b.ctx = ctx
+	return b
+}
+
+// Add method
+// An event without a source is replied to if it has a reply token, and
+// skipped otherwise since there is nobody to push to.
+func (b *ReplyBatch) Add(event *Event, messages ...Message) *ReplyBatch {
+	if event.Source == nil {
+		if event.ReplyToken != "" {
+			b.replies = append(b.replies, b.c.ReplyMessage(event.ReplyToken, messages...))
+		}
+		return b
+	}
+	key := string(event.Source.Type) + ":" + event.Source.ID()
+	if !b.replied[key] && event.ReplyToken != "" {
+		b.replied[key] = true
+		b.replies = append(b.replies, b.c.ReplyMessage(event.ReplyToken, messages...))
+		return b
+	}
+	b.pushes = append(b.pushes, b.c.PushMessage(event.Source.ID(), messages...))
+	return b
+}
+
+// Do method
+// Replies are sent before pushes because reply tokens expire shortly after the webhook.
+// All calls are attempted, and the first error encountered is returned.
+func (b *ReplyBatch) Do() error {
+	var firstErr error
+	for _, call := range b.replies {
+		if _, err := call.WithContext(b.ctx).Do(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, call := range b.pushes {
+		if _, err := call.WithContext(b.ctx).Do(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
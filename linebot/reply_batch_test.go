@@ -0,0 +1,98 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestReplyBatch(t *testing.T) {
+	source := &EventSource{
+		Type:   EventSourceTypeUser,
+		UserID: "U0cc15697597f61dd8b01cea8b027050e",
+	}
+	events := []*Event{
+		{
+			ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+			Type:       EventTypeMessage,
+			Source:     source,
+			Message:    &TextMessage{ID: "325708", Text: "first"},
+		},
+		{
+			ReplyToken: "mHuyWiB7yP5Zw52FIkcQobQuGDXCTB",
+			Type:       EventTypeMessage,
+			Source:     source,
+			Message:    &TextMessage{ID: "325709", Text: "second"},
+		},
+		{
+			ReplyToken: "oHuyWiB7yP5Zw52FIkcQobQuGDXCTC",
+			Type:       EventTypeMessage,
+			Message:    &TextMessage{ID: "325710", Text: "no source"},
+		},
+		{
+			Type:    EventTypeMessage,
+			Message: &TextMessage{ID: "325711", Text: "no source nor reply token"},
+		},
+	}
+	type request struct {
+		URLPath string
+		Body    string
+	}
+	want := []request{
+		{
+			URLPath: APIEndpointReplyMessage,
+			Body:    `{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"text","text":"first"}]}` + "\n",
+		},
+		{
+			URLPath: APIEndpointReplyMessage,
+			Body:    `{"replyToken":"oHuyWiB7yP5Zw52FIkcQobQuGDXCTC","messages":[{"type":"text","text":"no source"}]}` + "\n",
+		},
+		{
+			URLPath: APIEndpointPushMessage,
+			Body:    `{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"second"}]}` + "\n",
+		},
+	}
+
+	var got []request
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		got = append(got, request{URLPath: r.URL.Path, Body: string(body)})
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := client.NewReplyBatch()
+	for _, event := range events {
+		batch.Add(event, NewTextMessage(event.Message.(*TextMessage).Text))
+	}
+	if err := batch.Do(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Requests %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"sync"
+	"time"
+)
+
+// replyTokenTTL is how long used reply tokens are remembered.
+// Reply tokens expire well before that.
+const replyTokenTTL = 10 * time.Minute
+
+// WithReplyTokenTracking function
+// Once set, the client remembers the reply tokens used by ReplyMessage and warns
+// through the logger when a token is used again, which always fails.
+func WithReplyTokenTracking() ClientOption {
+	return func(client *Client) error {
+		client.replyTokens = &replyTokenSet{
+			used: map[string]time.Time{},
+			now:  time.Now,
+		}
+		return nil
+	}
+}
+
+type replyTokenSet struct {
+	mu   sync.Mutex
+	used map[string]time.Time
+	now  func() time.Time
+}
+
+// markUsed reports whether the token was already used.
+func (s *replyTokenSet) markUsed(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for t, usedAt := range s.used {
+		if now.Sub(usedAt) > replyTokenTTL {
+			delete(s.used, t)
+		}
+	}
+	if _, ok := s.used[token]; ok {
+		return true
+	}
+	s.used[token] = now
+	return false
+}
+
+func (client *Client) trackReplyToken(token string) {
+	if client.replyTokens == nil {
+		return
+	}
+	if client.replyTokens.markUsed(token) && client.logger != nil {
+		if client.redactLogs {
+			token = redactedHash(token)
+		}
+		client.logger.Printf("linebot: reply token %s is used more than once", token)
+	}
+}
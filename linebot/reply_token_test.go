@@ -0,0 +1,85 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplyTokenTracking(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := &testLogger{}
+	for _, option := range []ClientOption{WithLogger(logger), WithReplyTokenTracking()} {
+		if err := option(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	warnings := func() int {
+		count := 0
+		for _, line := range logger.lines {
+			if strings.Contains(line, "used more than once") {
+				count++
+			}
+		}
+		return count
+	}
+
+	var testCases = []struct {
+		ReplyToken string
+		Warnings   int
+	}{
+		{ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", Warnings: 0},
+		{ReplyToken: "b60d432864f44d079f6d8efe86cf404b", Warnings: 0},
+		{ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", Warnings: 1},
+	}
+	for i, tc := range testCases {
+		if _, err := client.ReplyMessage(tc.ReplyToken, NewTextMessage("Hello, world")).Do(); err != nil {
+			t.Fatal(err)
+		}
+		if got := warnings(); got != tc.Warnings {
+			t.Errorf("Warnings %d %d; want %d", i, got, tc.Warnings)
+		}
+	}
+}
+
+func TestReplyTokenSetTTL(t *testing.T) {
+	now := time.Now()
+	s := &replyTokenSet{
+		used: map[string]time.Time{},
+		now:  func() time.Time { return now },
+	}
+	if s.markUsed("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA") {
+		t.Errorf("markUsed true; want false")
+	}
+	if !s.markUsed("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA") {
+		t.Errorf("markUsed false; want true")
+	}
+	now = now.Add(replyTokenTTL + time.Second)
+	if s.markUsed("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA") {
+		t.Errorf("markUsed after TTL true; want false")
+	}
+}
@@ -17,25 +17,47 @@ package linebot
 import (
 	"encoding/json"
 	"io"
+	"mime"
 	"net/http"
+	"time"
 )
 
 // BasicResponse type
+// `RetryKey` is the X-Line-Retry-Key echoed by the response. The API doesn't
+// document echoing it, so it is empty unless the response has the header.
+// `Attempts` is the number of requests a push, reply or multicast took, which
+// is more than 1 when it was retried.
 type BasicResponse struct {
+	RetryKey string `json:"-"`
+	Attempts int    `json:"-"`
 }
 
-type errorResponseDetail struct {
+// ErrorDetail type
+// `Property` names the invalid field of the request, e.g. "messages[0].text".
+type ErrorDetail struct {
 	Message  string `json:"message"`
 	Property string `json:"property"`
 }
 
 // ErrorResponse type
 type ErrorResponse struct {
-	Message string                `json:"message"`
-	Details []errorResponseDetail `json:"details"`
+	Message string        `json:"message"`
+	Details []ErrorDetail `json:"details"`
+}
+
+// DetailFor method of ErrorResponse
+// It returns the first detail about `property`, or nil if there is none.
+func (r *ErrorResponse) DetailFor(property string) *ErrorDetail {
+	for i := range r.Details {
+		if r.Details[i].Property == property {
+			return &r.Details[i]
+		}
+	}
+	return nil
 }
 
 // UserProfileResponse type
+// `PicutureURL` and `StatusMessage` are empty when the user hasn't set them.
 type UserProfileResponse struct {
 	UserID        string `json:"userId"`
 	DisplayName   string `json:"displayName"`
@@ -43,11 +65,247 @@ type UserProfileResponse struct {
 	StatusMessage string `json:"statusMessage"`
 }
 
+// RichMenuIDResponse type
+type RichMenuIDResponse struct {
+	RichMenuID string `json:"richMenuId"`
+}
+
+// RichMenuResponse type
+type RichMenuResponse struct {
+	RichMenuID  string       `json:"richMenuId"`
+	Size        RichMenuSize `json:"size"`
+	Selected    bool         `json:"selected"`
+	Name        string       `json:"name"`
+	ChatBarText string       `json:"chatBarText"`
+	Areas       []AreaDetail `json:"areas"`
+}
+
+// MessagesNumberResponse type
+// `Status` is one of "ready", "unready" or "out_of_service".
+type MessagesNumberResponse struct {
+	Status  string `json:"status"`
+	Success int    `json:"success"`
+}
+
+// MessageQuotaResponse type
+type MessageQuotaResponse struct {
+	Type  MessageQuotaType `json:"type"`
+	Value int64            `json:"value"`
+}
+
+// MessageQuotaConsumptionResponse type
+type MessageQuotaConsumptionResponse struct {
+	TotalUsage int64 `json:"totalUsage"`
+}
+
+// ChatMode type
+type ChatMode string
+
+// ChatMode constants
+const (
+	ChatModeChat ChatMode = "chat"
+	ChatModeBot  ChatMode = "bot"
+)
+
+// MarkAsReadMode type
+type MarkAsReadMode string
+
+// MarkAsReadMode constants
+const (
+	MarkAsReadModeAuto   MarkAsReadMode = "auto"
+	MarkAsReadModeManual MarkAsReadMode = "manual"
+)
+
+// BotInfoResponse type
+// `PremiumID` and `PictureURL` are empty when they aren't set.
+type BotInfoResponse struct {
+	UserID         string         `json:"userId"`
+	BasicID        string         `json:"basicId"`
+	PremiumID      string         `json:"premiumId"`
+	DisplayName    string         `json:"displayName"`
+	PictureURL     string         `json:"pictureUrl"`
+	ChatMode       ChatMode       `json:"chatMode"`
+	MarkAsReadMode MarkAsReadMode `json:"markAsReadMode"`
+}
+
+// WebhookInfoResponse type
+type WebhookInfoResponse struct {
+	Endpoint string `json:"endpoint"`
+	Active   bool   `json:"active"`
+}
+
+// TestWebhookResponse type
+type TestWebhookResponse struct {
+	Success    bool      `json:"success"`
+	Timestamp  time.Time `json:"timestamp"`
+	StatusCode int       `json:"statusCode"`
+	Reason     string    `json:"reason"`
+	Detail     string    `json:"detail"`
+}
+
+// LinkTokenResponse type
+type LinkTokenResponse struct {
+	LinkToken string `json:"linkToken"`
+}
+
+// AudienceGroupResponse type
+// `Jobs` lists the uploads to the audience group with their status.
+type AudienceGroupResponse struct {
+	AudienceGroup *AudienceGroup      `json:"audienceGroup"`
+	Jobs          []*AudienceGroupJob `json:"jobs"`
+}
+
+// AudienceGroupsResponse type
+// `Page` starts from 1. `HasNextPage` is true if there are more audience groups to get.
+type AudienceGroupsResponse struct {
+	AudienceGroups                   []*AudienceGroup `json:"audienceGroups"`
+	HasNextPage                      bool             `json:"hasNextPage"`
+	TotalCount                       int              `json:"totalCount"`
+	ReadWriteAudienceGroupTotalCount int              `json:"readWriteAudienceGroupTotalCount"`
+	Page                             int              `json:"page"`
+	Size                             int              `json:"size"`
+}
+
+// CreateAudienceGroupResponse type
+// The audience group is IN_PROGRESS until the user IDs are processed.
+// Its status is given by GetAudienceGroup.
+type CreateAudienceGroupResponse struct {
+	AudienceGroupID int    `json:"audienceGroupId"`
+	Type            string `json:"type"`
+	Description     string `json:"description"`
+	Created         int64  `json:"created"`
+	Permission      string `json:"permission"`
+	ExpireTimestamp int64  `json:"expireTimestamp"`
+	IsIfaAudience   bool   `json:"isIfaAudience"`
+}
+
+// AudienceAuthorityLevelResponse type
+type AudienceAuthorityLevelResponse struct {
+	AuthorityLevel AudienceAuthorityLevel `json:"authorityLevel"`
+}
+
+// GenderRatio type
+type GenderRatio struct {
+	Gender     string  `json:"gender"`
+	Percentage float64 `json:"percentage"`
+}
+
+// AgeRatio type
+type AgeRatio struct {
+	Age        string  `json:"age"`
+	Percentage float64 `json:"percentage"`
+}
+
+// AreaRatio type
+type AreaRatio struct {
+	Area       string  `json:"area"`
+	Percentage float64 `json:"percentage"`
+}
+
+// AppTypeRatio type
+type AppTypeRatio struct {
+	AppType    string  `json:"appType"`
+	Percentage float64 `json:"percentage"`
+}
+
+// SubscriptionPeriodRatio type
+type SubscriptionPeriodRatio struct {
+	SubscriptionPeriod string  `json:"subscriptionPeriod"`
+	Percentage         float64 `json:"percentage"`
+}
+
+// FriendDemographicsResponse type
+// The breakdowns are empty when `Available` is false.
+type FriendDemographicsResponse struct {
+	Available           bool                       `json:"available"`
+	Genders             []*GenderRatio             `json:"genders"`
+	Ages                []*AgeRatio                `json:"ages"`
+	Areas               []*AreaRatio               `json:"areas"`
+	AppTypes            []*AppTypeRatio            `json:"appTypes"`
+	SubscriptionPeriods []*SubscriptionPeriodRatio `json:"subscriptionPeriods"`
+}
+
+// MessagesNumberDeliveryResponse type
+// `Status` is one of "ready", "unready" or "out_of_service". The counts are
+// only set when it is "ready".
+type MessagesNumberDeliveryResponse struct {
+	Status          string `json:"status"`
+	Broadcast       int64  `json:"broadcast"`
+	Targeting       int64  `json:"targeting"`
+	AutoResponse    int64  `json:"autoResponse"`
+	WelcomeResponse int64  `json:"welcomeResponse"`
+	Chat            int64  `json:"chat"`
+	APIBroadcast    int64  `json:"apiBroadcast"`
+	APIPush         int64  `json:"apiPush"`
+	APIMulticast    int64  `json:"apiMulticast"`
+	APIReply        int64  `json:"apiReply"`
+}
+
+// MessagesNumberFollowersResponse type
+// `Status` is one of "ready", "unready" or "out_of_service". The counts are
+// only set when it is "ready".
+type MessagesNumberFollowersResponse struct {
+	Status          string `json:"status"`
+	Followers       int64  `json:"followers"`
+	TargetedReaches int64  `json:"targetedReaches"`
+	Blocks          int64  `json:"blocks"`
+}
+
+// OverviewStatistics type
+// `Timestamp` is in seconds. Counts which are too small to be disclosed are 0,
+// and counts which don't apply, such as plays of a message without media, are -1.
+type OverviewStatistics struct {
+	RequestID                   string `json:"requestId"`
+	Timestamp                   int64  `json:"timestamp"`
+	Delivered                   int64  `json:"delivered"`
+	UniqueImpression            int64  `json:"uniqueImpression"`
+	UniqueClick                 int64  `json:"uniqueClick"`
+	UniqueMediaPlayed           int64  `json:"uniqueMediaPlayed"`
+	UniqueMediaPlayed100Percent int64  `json:"uniqueMediaPlayed100Percent"`
+}
+
+// MessageStatistics type
+// `Seq` is the 1-based position of the message in the request.
+type MessageStatistics struct {
+	Seq                         int   `json:"seq"`
+	Impression                  int64 `json:"impression"`
+	MediaPlayed                 int64 `json:"mediaPlayed"`
+	MediaPlayed25Percent        int64 `json:"mediaPlayed25Percent"`
+	MediaPlayed50Percent        int64 `json:"mediaPlayed50Percent"`
+	MediaPlayed75Percent        int64 `json:"mediaPlayed75Percent"`
+	MediaPlayed100Percent       int64 `json:"mediaPlayed100Percent"`
+	UniqueMediaPlayed           int64 `json:"uniqueMediaPlayed"`
+	UniqueMediaPlayed25Percent  int64 `json:"uniqueMediaPlayed25Percent"`
+	UniqueMediaPlayed50Percent  int64 `json:"uniqueMediaPlayed50Percent"`
+	UniqueMediaPlayed75Percent  int64 `json:"uniqueMediaPlayed75Percent"`
+	UniqueMediaPlayed100Percent int64 `json:"uniqueMediaPlayed100Percent"`
+}
+
+// ClickStatistics type
+// `Seq` is the 1-based position of the message containing `URL`.
+type ClickStatistics struct {
+	Seq                  int    `json:"seq"`
+	URL                  string `json:"url"`
+	Click                int64  `json:"click"`
+	UniqueClick          int64  `json:"uniqueClick"`
+	UniqueClickOfRequest int64  `json:"uniqueClickOfRequest"`
+}
+
+// UserInteractionStatisticsResponse type
+type UserInteractionStatisticsResponse struct {
+	Overview OverviewStatistics  `json:"overview"`
+	Messages []MessageStatistics `json:"messages"`
+	Clicks   []ClickStatistics   `json:"clicks"`
+}
+
 // MessageContentResponse type
+// `FileName` is taken from the Content-Disposition header and is empty when
+// the header is absent, since the content URL doesn't carry a file name.
 type MessageContentResponse struct {
 	Content       io.ReadCloser
 	ContentLength int64
 	ContentType   string
+	FileName      string
 }
 
 func checkResponse(res *http.Response) error {
@@ -56,49 +314,269 @@ func checkResponse(res *http.Response) error {
 		result := ErrorResponse{}
 		if err := decoder.Decode(&result); err != nil {
 			return &APIError{
-				Code: res.StatusCode,
+				Code:      res.StatusCode,
+				RequestID: res.Header.Get("X-Line-Request-Id"),
 			}
 		}
 		return &APIError{
-			Code:     res.StatusCode,
-			Response: &result,
+			Code:      res.StatusCode,
+			Response:  &result,
+			RequestID: res.Header.Get("X-Line-Request-Id"),
 		}
 	}
 	return nil
 }
 
-func decodeToBasicResponse(res *http.Response) (*BasicResponse, error) {
+// decodeError wraps an error decoding a successful response with the endpoint.
+func decodeError(res *http.Response, err error) error {
+	endpoint := ""
+	if res.Request != nil {
+		endpoint = res.Request.URL.Path
+	}
+	return &DecodeError{
+		Endpoint: endpoint,
+		Err:      err,
+	}
+}
+
+func decodeToBasicResponse(res *http.Response, codec JSONCodec) (*BasicResponse, error) {
 	if err := checkResponse(res); err != nil {
 		return nil, err
 	}
-	decoder := json.NewDecoder(res.Body)
 	result := BasicResponse{}
-	if err := decoder.Decode(&result); err != nil {
+	if err := decodeJSON(res, codec, &result); err != nil {
 		return nil, err
 	}
+	result.RetryKey = res.Header.Get("X-Line-Retry-Key")
 	return &result, nil
 }
 
-func decodeToUserProfileResponse(res *http.Response) (*UserProfileResponse, error) {
+func decodeToUserProfileResponse(res *http.Response, codec JSONCodec) (*UserProfileResponse, error) {
 	if err := checkResponse(res); err != nil {
 		return nil, err
 	}
-	decoder := json.NewDecoder(res.Body)
 	result := UserProfileResponse{}
-	if err := decoder.Decode(&result); err != nil {
+	if err := decodeJSON(res, codec, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-func decodeToMessageContentResponse(res *http.Response) (*MessageContentResponse, error) {
+func decodeToRichMenuIDResponse(res *http.Response, codec JSONCodec) (*RichMenuIDResponse, error) {
 	if err := checkResponse(res); err != nil {
 		return nil, err
 	}
+	result := RichMenuIDResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToRichMenuResponse(res *http.Response, codec JSONCodec) (*RichMenuResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := RichMenuResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToRichMenuListResponse(res *http.Response, codec JSONCodec) ([]*RichMenuResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := struct {
+		RichMenus []*RichMenuResponse `json:"richmenus"`
+	}{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return result.RichMenus, nil
+}
+
+func decodeToMessagesNumberResponse(res *http.Response, codec JSONCodec) (*MessagesNumberResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := MessagesNumberResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToMessageQuotaResponse(res *http.Response, codec JSONCodec) (*MessageQuotaResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := MessageQuotaResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToMessageQuotaConsumptionResponse(res *http.Response, codec JSONCodec) (*MessageQuotaConsumptionResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := MessageQuotaConsumptionResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToBotInfoResponse(res *http.Response, codec JSONCodec) (*BotInfoResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := BotInfoResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToWebhookInfoResponse(res *http.Response, codec JSONCodec) (*WebhookInfoResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := WebhookInfoResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToTestWebhookResponse(res *http.Response, codec JSONCodec) (*TestWebhookResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := TestWebhookResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToLinkTokenResponse(res *http.Response, codec JSONCodec) (*LinkTokenResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := LinkTokenResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToAudienceGroupResponse(res *http.Response, codec JSONCodec) (*AudienceGroupResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := AudienceGroupResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToAudienceGroupsResponse(res *http.Response, codec JSONCodec) (*AudienceGroupsResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := AudienceGroupsResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToCreateAudienceGroupResponse(res *http.Response, codec JSONCodec) (*CreateAudienceGroupResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := CreateAudienceGroupResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToAudienceAuthorityLevelResponse(res *http.Response, codec JSONCodec) (*AudienceAuthorityLevelResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := AudienceAuthorityLevelResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToFriendDemographicsResponse(res *http.Response, codec JSONCodec) (*FriendDemographicsResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := FriendDemographicsResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToMessagesNumberDeliveryResponse(res *http.Response, codec JSONCodec) (*MessagesNumberDeliveryResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := MessagesNumberDeliveryResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToMessagesNumberFollowersResponse(res *http.Response, codec JSONCodec) (*MessagesNumberFollowersResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := MessagesNumberFollowersResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToUserInteractionStatisticsResponse(res *http.Response, codec JSONCodec) (*UserInteractionStatisticsResponse, error) {
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	result := UserInteractionStatisticsResponse{}
+	if err := decodeJSON(res, codec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func decodeToMessageContentResponse(res *http.Response) (*MessageContentResponse, error) {
+	if res.StatusCode != http.StatusPartialContent {
+		if err := checkResponse(res); err != nil {
+			return nil, err
+		}
+	}
 	result := MessageContentResponse{
 		Content:       res.Body,
 		ContentType:   res.Header.Get("Content-Type"),
 		ContentLength: res.ContentLength,
 	}
+	if disposition := res.Header.Get("Content-Disposition"); disposition != "" {
+		if _, params, err := mime.ParseMediaType(disposition); err == nil {
+			result.FileName = params["filename"]
+		}
+	}
 	return &result, nil
 }
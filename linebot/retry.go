@@ -0,0 +1,131 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// defaultRetryBackoff is the wait before the first retry.
+// It doubles for each following retry.
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// WithRetries function
+// Once set, push and multicast requests failing with a network error or a 5xx
+// status code are retried up to `retries` times. The same X-Line-Retry-Key is
+// sent on every attempt so that the messages are delivered only once; it is
+// generated unless given by WithRetryKey. A retry answered with 409 Conflict
+// means that an earlier attempt was accepted. Replies are not retried.
+func WithRetries(retries int) ClientOption {
+	return func(client *Client) error {
+		if retries < 0 {
+			return errors.New("negative retries")
+		}
+		client.retries = retries
+		client.retryBackoff = defaultRetryBackoff
+		return nil
+	}
+}
+
+// newRetryKey generates a version 4 UUID.
+func (client *Client) newRetryKey() (string, error) {
+	var b [16]byte
+	if _, err := io.ReadFull(client.random, b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// postMessages posts `body` with `retryKey` if it is not empty.
+// The request is retried if `retry` is true and retries are enabled. It also
+// returns the number of requests sent.
+func (client *Client) postMessages(ctx context.Context, endpoint string, body []byte, retryKey string, retry bool) (*http.Response, int, error) {
+	maxAttempts := 1
+	if retry && client.retries > 0 {
+		maxAttempts += client.retries
+		if retryKey == "" {
+			key, err := client.newRetryKey()
+			if err != nil {
+				return nil, 0, err
+			}
+			retryKey = key
+		}
+	}
+	wait := client.retryBackoff
+	for attempt := 1; ; attempt++ {
+		req, err := client.newMessagesRequest(endpoint, body, retryKey)
+		if err != nil {
+			return nil, attempt - 1, err
+		}
+		if client.limiter != nil {
+			if err := client.limiter.wait(ctx); err != nil {
+				return nil, attempt - 1, err
+			}
+		}
+		res, err := client.do(ctx, req, body)
+		if attempt == maxAttempts || !retryable(res, err) {
+			return res, attempt, err
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, attempt, err
+		}
+		wait *= 2
+	}
+}
+
+func (client *Client) newMessagesRequest(endpoint string, body []byte, retryKey string) (*http.Request, error) {
+	req, err := client.newPostRequest(endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if retryKey != "" {
+		req.Header.Set("X-Line-Retry-Key", retryKey)
+	}
+	return req, nil
+}
+
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return err != context.Canceled && err != context.DeadlineExceeded
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+// sleep waits for `d` unless `ctx` is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
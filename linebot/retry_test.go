@@ -0,0 +1,154 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRetryKeyGeneration(t *testing.T) {
+	var testCases = []struct {
+		RetryKey   string
+		StatusCode []int
+		Want       []string
+		Error      error
+	}{
+		{
+			// Generated and reused on the retry
+			StatusCode: []int{500, 200},
+			Want:       []string{"00010203-0405-4607-8809-0a0b0c0d0e0f", "00010203-0405-4607-8809-0a0b0c0d0e0f"},
+		},
+		{
+			// Given by WithRetryKey
+			RetryKey:   "123e4567-e89b-12d3-a456-426614174000",
+			StatusCode: []int{503, 200},
+			Want:       []string{"123e4567-e89b-12d3-a456-426614174000", "123e4567-e89b-12d3-a456-426614174000"},
+		},
+		{
+			// Not retried
+			StatusCode: []int{400},
+			Want:       []string{"00010203-0405-4607-8809-0a0b0c0d0e0f"},
+			Error:      &APIError{Code: 400, Response: &ErrorResponse{}},
+		},
+		{
+			// Out of retries
+			StatusCode: []int{500, 500, 500},
+			Want:       []string{"00010203-0405-4607-8809-0a0b0c0d0e0f", "00010203-0405-4607-8809-0a0b0c0d0e0f", "00010203-0405-4607-8809-0a0b0c0d0e0f"},
+			Error:      &APIError{Code: 500, Response: &ErrorResponse{}},
+		},
+	}
+
+	var gotRetryKeys []string
+	var statusCodes []int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		gotRetryKeys = append(gotRetryKeys, r.Header.Get("X-Line-Retry-Key"))
+		w.WriteHeader(statusCodes[0])
+		statusCodes = statusCodes[1:]
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WithRetries(2)(client); err != nil {
+		t.Fatal(err)
+	}
+	client.retryBackoff = 0
+	for i, tc := range testCases {
+		gotRetryKeys = nil
+		statusCodes = tc.StatusCode
+		client.random = bytes.NewReader([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+		_, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).WithRetryKey(tc.RetryKey).Do()
+		if !reflect.DeepEqual(err, tc.Error) {
+			t.Errorf("Error %d %v; want %v", i, err, tc.Error)
+		}
+		if !reflect.DeepEqual(gotRetryKeys, tc.Want) {
+			t.Errorf("X-Line-Retry-Key %d %v; want %v", i, gotRetryKeys, tc.Want)
+		}
+	}
+}
+
+func TestNewRetryKey(t *testing.T) {
+	client, err := New("testsecret", "testtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		key, err := client.newRetryKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(key) != 36 || key[14] != '4' || !bytes.ContainsAny([]byte{key[19]}, "89ab") {
+			t.Errorf("retry key %s; want a version 4 UUID", key)
+		}
+		if keys[key] {
+			t.Errorf("retry key %s is generated twice", key)
+		}
+		keys[key] = true
+	}
+}
+
+func TestRetryAttempts(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		requests++
+		// fails every other request
+		if requests%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WithRetries(2)(client); err != nil {
+		t.Fatal(err)
+	}
+	client.retryBackoff = 0
+
+	res, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Attempts != 2 {
+		t.Errorf("Attempts %d; want 2", res.Attempts)
+	}
+	res, err = client.Multicast([]string{"U0cc15697597f61dd8b01cea8b027050e"}, NewTextMessage("Hello, world")).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Attempts != 2 {
+		t.Errorf("Attempts %d; want 2", res.Attempts)
+	}
+	// replies are not retried
+	requests = 1
+	res, err = client.ReplyMessage("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", NewTextMessage("Hello, world")).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Attempts != 1 {
+		t.Errorf("Attempts %d; want 1", res.Attempts)
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"golang.org/x/net/context"
+)
+
+// RichMenuActionType type
+type RichMenuActionType string
+
+// RichMenuActionType constants
+const (
+	RichMenuActionTypeURI      RichMenuActionType = "uri"
+	RichMenuActionTypeMessage  RichMenuActionType = "message"
+	RichMenuActionTypePostback RichMenuActionType = "postback"
+)
+
+// RichMenuSize type
+type RichMenuSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// RichMenuAction type
+type RichMenuAction struct {
+	Type RichMenuActionType `json:"type"`
+	URI  string             `json:"uri,omitempty"`
+	Text string             `json:"text,omitempty"`
+	Data string             `json:"data,omitempty"`
+}
+
+// AreaDetail type
+type AreaDetail struct {
+	Bounds Bounds         `json:"bounds"`
+	Action RichMenuAction `json:"action"`
+}
+
+// RichMenu type
+type RichMenu struct {
+	Size        RichMenuSize `json:"size"`
+	Selected    bool         `json:"selected"`
+	Name        string       `json:"name"`
+	ChatBarText string       `json:"chatBarText"`
+	Areas       []AreaDetail `json:"areas"`
+}
+
+// CreateRichMenu method
+func (client *Client) CreateRichMenu(richMenu RichMenu) *CreateRichMenuCall {
+	return &CreateRichMenuCall{
+		c:        client,
+		richMenu: richMenu,
+	}
+}
+
+// CreateRichMenuCall type
+type CreateRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+
+	richMenu RichMenu
+}
+
+// WithContext method
+func (call *CreateRichMenuCall) WithContext(ctx context.Context) *CreateRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+func (call *CreateRichMenuCall) encodeJSON(w io.Writer) error {
+	return call.c.encodeJSON(w, &call.richMenu)
+}
+
+// Do method
+func (call *CreateRichMenuCall) Do() (*RichMenuIDResponse, error) {
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	res, err := call.c.post(call.ctx, APIEndpointCreateRichMenu, buf.Bytes())
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToRichMenuIDResponse(res, call.c.codec)
+}
+
+// GetRichMenu method
+func (client *Client) GetRichMenu(richMenuID string) *GetRichMenuCall {
+	return &GetRichMenuCall{
+		c:          client,
+		richMenuID: richMenuID,
+	}
+}
+
+// GetRichMenuCall type
+type GetRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+
+	richMenuID string
+}
+
+// WithContext method
+func (call *GetRichMenuCall) WithContext(ctx context.Context) *GetRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetRichMenuCall) Do() (*RichMenuResponse, error) {
+	endpoint := fmt.Sprintf(APIEndpointGetRichMenu, call.richMenuID)
+	res, err := call.c.get(call.ctx, endpoint, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToRichMenuResponse(res, call.c.codec)
+}
+
+// DeleteRichMenu method
+func (client *Client) DeleteRichMenu(richMenuID string) *DeleteRichMenuCall {
+	return &DeleteRichMenuCall{
+		c:          client,
+		richMenuID: richMenuID,
+	}
+}
+
+// DeleteRichMenuCall type
+type DeleteRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+
+	richMenuID string
+}
+
+// WithContext method
+func (call *DeleteRichMenuCall) WithContext(ctx context.Context) *DeleteRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *DeleteRichMenuCall) Do() (*BasicResponse, error) {
+	endpoint := fmt.Sprintf(APIEndpointDeleteRichMenu, call.richMenuID)
+	res, err := call.c.delete(call.ctx, endpoint)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// GetRichMenuList method
+func (client *Client) GetRichMenuList() *GetRichMenuListCall {
+	return &GetRichMenuListCall{
+		c: client,
+	}
+}
+
+// GetRichMenuListCall type
+type GetRichMenuListCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetRichMenuListCall) WithContext(ctx context.Context) *GetRichMenuListCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetRichMenuListCall) Do() ([]*RichMenuResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointListRichMenu, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToRichMenuListResponse(res, call.c.codec)
+}
+
+// UploadRichMenuImage method
+// `contentType` must be either "image/jpeg" or "image/png".
+func (client *Client) UploadRichMenuImage(richMenuID string, img io.Reader, contentType string) *UploadRichMenuImageCall {
+	return &UploadRichMenuImageCall{
+		c:           client,
+		richMenuID:  richMenuID,
+		img:         img,
+		contentType: contentType,
+	}
+}
+
+// UploadRichMenuImageCall type
+type UploadRichMenuImageCall struct {
+	c   *Client
+	ctx context.Context
+
+	richMenuID  string
+	img         io.Reader
+	contentType string
+}
+
+// WithContext method
+func (call *UploadRichMenuImageCall) WithContext(ctx context.Context) *UploadRichMenuImageCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *UploadRichMenuImageCall) Do() (*BasicResponse, error) {
+	if call.contentType != "image/jpeg" && call.contentType != "image/png" {
+		return nil, ErrInvalidContentType
+	}
+	endpoint := fmt.Sprintf(APIEndpointUploadRichMenuImage, call.richMenuID)
+	req, err := http.NewRequest("POST", call.c.url(endpoint), call.img)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", call.contentType)
+	res, err := call.c.do(call.ctx, req, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// LinkUserRichMenu method
+func (client *Client) LinkUserRichMenu(userID, richMenuID string) *LinkUserRichMenuCall {
+	return &LinkUserRichMenuCall{
+		c:          client,
+		userID:     userID,
+		richMenuID: richMenuID,
+	}
+}
+
+// LinkUserRichMenuCall type
+type LinkUserRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+
+	userID     string
+	richMenuID string
+}
+
+// WithContext method
+func (call *LinkUserRichMenuCall) WithContext(ctx context.Context) *LinkUserRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *LinkUserRichMenuCall) Do() (*BasicResponse, error) {
+	endpoint := fmt.Sprintf(APIEndpointLinkUserRichMenu, call.userID, call.richMenuID)
+	res, err := call.c.post(call.ctx, endpoint, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// UnlinkUserRichMenu method
+func (client *Client) UnlinkUserRichMenu(userID string) *UnlinkUserRichMenuCall {
+	return &UnlinkUserRichMenuCall{
+		c:      client,
+		userID: userID,
+	}
+}
+
+// UnlinkUserRichMenuCall type
+type UnlinkUserRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+
+	userID string
+}
+
+// WithContext method
+func (call *UnlinkUserRichMenuCall) WithContext(ctx context.Context) *UnlinkUserRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *UnlinkUserRichMenuCall) Do() (*BasicResponse, error) {
+	endpoint := fmt.Sprintf(APIEndpointUnlinkUserRichMenu, call.userID)
+	res, err := call.c.delete(call.ctx, endpoint)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// SetDefaultRichMenu method
+func (client *Client) SetDefaultRichMenu(richMenuID string) *SetDefaultRichMenuCall {
+	return &SetDefaultRichMenuCall{
+		c:          client,
+		richMenuID: richMenuID,
+	}
+}
+
+// SetDefaultRichMenuCall type
+type SetDefaultRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+
+	richMenuID string
+}
+
+// WithContext method
+func (call *SetDefaultRichMenuCall) WithContext(ctx context.Context) *SetDefaultRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *SetDefaultRichMenuCall) Do() (*BasicResponse, error) {
+	endpoint := fmt.Sprintf(APIEndpointSetDefaultRichMenu, call.richMenuID)
+	res, err := call.c.post(call.ctx, endpoint, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// GetDefaultRichMenu method
+func (client *Client) GetDefaultRichMenu() *GetDefaultRichMenuCall {
+	return &GetDefaultRichMenuCall{
+		c: client,
+	}
+}
+
+// GetDefaultRichMenuCall type
+type GetDefaultRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetDefaultRichMenuCall) WithContext(ctx context.Context) *GetDefaultRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetDefaultRichMenuCall) Do() (*RichMenuIDResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointDefaultRichMenu, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToRichMenuIDResponse(res, call.c.codec)
+}
+
+// CancelDefaultRichMenu method
+func (client *Client) CancelDefaultRichMenu() *CancelDefaultRichMenuCall {
+	return &CancelDefaultRichMenuCall{
+		c: client,
+	}
+}
+
+// CancelDefaultRichMenuCall type
+type CancelDefaultRichMenuCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *CancelDefaultRichMenuCall) WithContext(ctx context.Context) *CancelDefaultRichMenuCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *CancelDefaultRichMenuCall) Do() (*BasicResponse, error) {
+	res, err := call.c.delete(call.ctx, APIEndpointDefaultRichMenu)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
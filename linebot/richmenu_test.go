@@ -0,0 +1,507 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestCreateRichMenu(t *testing.T) {
+	type want struct {
+		RequestBody []byte
+		Response    *RichMenuIDResponse
+		Error       error
+	}
+	var testCases = []struct {
+		Request      RichMenu
+		Response     []byte
+		ResponseCode int
+		Want         want
+	}{
+		{
+			Request: RichMenu{
+				Size:        RichMenuSize{Width: 2500, Height: 1686},
+				Selected:    false,
+				Name:        "Menu1",
+				ChatBarText: "ChatText",
+				Areas: []AreaDetail{
+					{
+						Bounds: Bounds{X: 0, Y: 0, Width: 1250, Height: 1686},
+						Action: RichMenuAction{
+							Type: RichMenuActionTypePostback,
+							Data: "action=buy&itemid=123",
+						},
+					},
+					{
+						Bounds: Bounds{X: 1250, Y: 0, Width: 1250, Height: 1686},
+						Action: RichMenuAction{
+							Type: RichMenuActionTypeURI,
+							URI:  "https://example.com/",
+						},
+					},
+				},
+			},
+			ResponseCode: 200,
+			Response:     []byte(`{"richMenuId":"abcefg"}`),
+			Want: want{
+				RequestBody: []byte(`{"size":{"width":2500,"height":1686},"selected":false,"name":"Menu1","chatBarText":"ChatText","areas":[{"bounds":{"x":0,"y":0,"width":1250,"height":1686},"action":{"type":"postback","data":"action=buy\u0026itemid=123"}},{"bounds":{"x":1250,"y":0,"width":1250,"height":1686},"action":{"type":"uri","uri":"https://example.com/"}}]}` + "\n"),
+				Response:    &RichMenuIDResponse{RichMenuID: "abcefg"},
+			},
+		},
+		{
+			// Bad request
+			Request:      RichMenu{},
+			ResponseCode: 400,
+			Response:     []byte(`{"message":"Invalid size"}`),
+			Want: want{
+				RequestBody: []byte(`{"size":{"width":0,"height":0},"selected":false,"name":"","chatBarText":"","areas":null}` + "\n"),
+				Error: &APIError{
+					Code: 400,
+					Response: &ErrorResponse{
+						Message: "Invalid size",
+					},
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodPost {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodPost)
+		}
+		if r.URL.Path != APIEndpointCreateRichMenu {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointCreateRichMenu)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.Want.RequestBody) {
+			t.Errorf("RequestBody %s; want %s", body, tc.Want.RequestBody)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := client.CreateRichMenu(tc.Request).Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if tc.Want.Response != nil {
+			if !reflect.DeepEqual(res, tc.Want.Response) {
+				t.Errorf("Response %d %q; want %q", i, res, tc.Want.Response)
+			}
+		}
+	}
+}
+
+func TestCreateRichMenuWithContext(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{"richMenuId":"abcefg"}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = client.CreateRichMenu(RichMenu{}).WithContext(ctx).Do()
+	if err != context.DeadlineExceeded {
+		t.Errorf("err %v; want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestUploadRichMenuImage(t *testing.T) {
+	type want struct {
+		URLPath     string
+		ContentType string
+		RequestBody []byte
+		Response    *BasicResponse
+		Error       error
+	}
+	var testCases = []struct {
+		RichMenuID   string
+		Image        []byte
+		ContentType  string
+		ResponseCode int
+		Response     []byte
+		Want         want
+	}{
+		{
+			RichMenuID:   "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5",
+			Image:        []byte{0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a},
+			ContentType:  "image/png",
+			ResponseCode: 200,
+			Response:     []byte(`{}`),
+			Want: want{
+				URLPath:     fmt.Sprintf(APIEndpointUploadRichMenuImage, "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5"),
+				ContentType: "image/png",
+				RequestBody: []byte{0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a},
+				Response:    &BasicResponse{},
+			},
+		},
+		{
+			// Unsupported content type
+			RichMenuID:  "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5",
+			Image:       []byte("GIF89a"),
+			ContentType: "image/gif",
+			Want: want{
+				Error: ErrInvalidContentType,
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodPost {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodPost)
+		}
+		if r.URL.Path != tc.Want.URLPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, tc.Want.URLPath)
+		}
+		if r.Header.Get("Content-Type") != tc.Want.ContentType {
+			t.Errorf("ContentType %s; want %s", r.Header.Get("Content-Type"), tc.Want.ContentType)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.Want.RequestBody) {
+			t.Errorf("RequestBody %X; want %X", body, tc.Want.RequestBody)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := client.UploadRichMenuImage(tc.RichMenuID, bytes.NewReader(tc.Image), tc.ContentType).Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestLinkUserRichMenu(t *testing.T) {
+	type want struct {
+		URLPath     string
+		RequestBody []byte
+		Response    *BasicResponse
+		Error       error
+	}
+	var testCases = []struct {
+		UserID       string
+		RichMenuID   string
+		ResponseCode int
+		Response     []byte
+		Want         want
+	}{
+		{
+			UserID:       "U0cc15697597f61dd8b01cea8b027050e",
+			RichMenuID:   "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5",
+			ResponseCode: 200,
+			Response:     []byte(`{}`),
+			Want: want{
+				URLPath:     fmt.Sprintf(APIEndpointLinkUserRichMenu, "U0cc15697597f61dd8b01cea8b027050e", "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5"),
+				RequestBody: []byte(""),
+				Response:    &BasicResponse{},
+			},
+		},
+		{
+			// Not found
+			UserID:       "U0cc15697597f61dd8b01cea8b027050e",
+			RichMenuID:   "richmenu-00000000000000000000000000000000",
+			ResponseCode: 404,
+			Response:     []byte(`{"message":"Not found"}`),
+			Want: want{
+				URLPath:     fmt.Sprintf(APIEndpointLinkUserRichMenu, "U0cc15697597f61dd8b01cea8b027050e", "richmenu-00000000000000000000000000000000"),
+				RequestBody: []byte(""),
+				Error: &APIError{
+					Code: 404,
+					Response: &ErrorResponse{
+						Message: "Not found",
+					},
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodPost {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodPost)
+		}
+		if r.URL.Path != tc.Want.URLPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, tc.Want.URLPath)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.Want.RequestBody) {
+			t.Errorf("RequestBody %s; want %s", body, tc.Want.RequestBody)
+		}
+		w.WriteHeader(tc.ResponseCode)
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := client.LinkUserRichMenu(tc.UserID, tc.RichMenuID).Do()
+		if tc.Want.Error != nil {
+			if !reflect.DeepEqual(err, tc.Want.Error) {
+				t.Errorf("Error %d %q; want %q", i, err, tc.Want.Error)
+			}
+		} else {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
+
+func TestDefaultRichMenu(t *testing.T) {
+	richMenuID := "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5"
+	var testCases = []struct {
+		Do         func(*Client) (interface{}, error)
+		WantMethod string
+		WantPath   string
+		Response   []byte
+		Want       interface{}
+	}{
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.SetDefaultRichMenu(richMenuID).Do()
+			},
+			WantMethod: http.MethodPost,
+			WantPath:   fmt.Sprintf(APIEndpointSetDefaultRichMenu, richMenuID),
+			Response:   []byte(`{}`),
+			Want:       &BasicResponse{},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.GetDefaultRichMenu().Do()
+			},
+			WantMethod: http.MethodGet,
+			WantPath:   APIEndpointDefaultRichMenu,
+			Response:   []byte(`{"richMenuId":"richmenu-8dfdfc571eca39c0ffcd1f799519c5b5"}`),
+			Want:       &RichMenuIDResponse{RichMenuID: richMenuID},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.CancelDefaultRichMenu().Do()
+			},
+			WantMethod: http.MethodDelete,
+			WantPath:   APIEndpointDefaultRichMenu,
+			Response:   []byte(`{}`),
+			Want:       &BasicResponse{},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != tc.WantMethod {
+			t.Errorf("Method %s; want %s", r.Method, tc.WantMethod)
+		}
+		if r.URL.Path != tc.WantPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, tc.WantPath)
+		}
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := tc.Do(client)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(res, tc.Want) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want)
+		}
+	}
+}
+
+func TestGetRichMenu(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		wantPath := fmt.Sprintf(APIEndpointGetRichMenu, "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5")
+		if r.URL.Path != wantPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, wantPath)
+		}
+		w.Write([]byte(`{"richMenuId":"richmenu-8dfdfc571eca39c0ffcd1f799519c5b5","size":{"width":2500,"height":1686},"selected":false,"name":"Menu1","chatBarText":"ChatText","areas":[{"bounds":{"x":0,"y":0,"width":2500,"height":1686},"action":{"type":"postback","data":"action=buy\u0026itemid=123"}}]}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetRichMenu("richmenu-8dfdfc571eca39c0ffcd1f799519c5b5").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &RichMenuResponse{
+		RichMenuID:  "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5",
+		Size:        RichMenuSize{Width: 2500, Height: 1686},
+		Name:        "Menu1",
+		ChatBarText: "ChatText",
+		Areas: []AreaDetail{
+			{
+				Bounds: Bounds{X: 0, Y: 0, Width: 2500, Height: 1686},
+				Action: RichMenuAction{Type: RichMenuActionTypePostback, Data: "action=buy&itemid=123"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Response %v; want %v", res, want)
+	}
+}
+
+func TestGetRichMenuList(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method != http.MethodGet {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodGet)
+		}
+		if r.URL.Path != APIEndpointListRichMenu {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, APIEndpointListRichMenu)
+		}
+		w.Write([]byte(`{"richmenus":[{"richMenuId":"richmenu-8dfdfc571eca39c0ffcd1f799519c5b5","size":{"width":2500,"height":1686},"selected":false,"name":"Menu1","chatBarText":"ChatText","areas":[{"bounds":{"x":0,"y":0,"width":2500,"height":1686},"action":{"type":"message","text":"hello"}}]},{"richMenuId":"richmenu-d46b9bc4b7e6b8d1c4ab0e4b5a1e2f0c","size":{"width":2500,"height":843},"selected":true,"name":"Menu2","chatBarText":"Tap here","areas":[{"bounds":{"x":0,"y":0,"width":2500,"height":843},"action":{"type":"uri","uri":"https://example.com/"}}]}]}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetRichMenuList().Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*RichMenuResponse{
+		{
+			RichMenuID:  "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5",
+			Size:        RichMenuSize{Width: 2500, Height: 1686},
+			Name:        "Menu1",
+			ChatBarText: "ChatText",
+			Areas: []AreaDetail{
+				{
+					Bounds: Bounds{X: 0, Y: 0, Width: 2500, Height: 1686},
+					Action: RichMenuAction{Type: RichMenuActionTypeMessage, Text: "hello"},
+				},
+			},
+		},
+		{
+			RichMenuID:  "richmenu-d46b9bc4b7e6b8d1c4ab0e4b5a1e2f0c",
+			Size:        RichMenuSize{Width: 2500, Height: 843},
+			Selected:    true,
+			Name:        "Menu2",
+			ChatBarText: "Tap here",
+			Areas: []AreaDetail{
+				{
+					Bounds: Bounds{X: 0, Y: 0, Width: 2500, Height: 843},
+					Action: RichMenuAction{Type: RichMenuActionTypeURI, URI: "https://example.com/"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Response %v; want %v", res, want)
+	}
+}
+
+func TestDeleteRichMenu(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method != http.MethodDelete {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodDelete)
+		}
+		wantPath := fmt.Sprintf(APIEndpointDeleteRichMenu, "richmenu-8dfdfc571eca39c0ffcd1f799519c5b5")
+		if r.URL.Path != wantPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, wantPath)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.DeleteRichMenu("richmenu-8dfdfc571eca39c0ffcd1f799519c5b5").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, &BasicResponse{}) {
+		t.Errorf("Response %v; want %v", res, &BasicResponse{})
+	}
+}
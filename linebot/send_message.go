@@ -16,12 +16,58 @@ package linebot
 
 import (
 	"bytes"
-	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
 
 	"golang.org/x/net/context"
 )
 
+type validator interface {
+	Validate() error
+}
+
+// maxMessages is the maximum number of messages sent in a request
+const maxMessages = 5
+
+// validateMessages runs local validations and reports the first invalid field
+// with its index in the same form as the API, e.g. "messages[0].text".
+func validateMessages(messages []Message) error {
+	if len(messages) > maxMessages {
+		return ErrTooManyMessages
+	}
+	for i, m := range messages {
+		if v, ok := m.(validator); ok {
+			if err := v.Validate(); err != nil {
+				return prefixValidationError(fmt.Sprintf("messages[%d]", i), err)
+			}
+		}
+		if quickReply := quickReplyOf(m); quickReply != nil {
+			if err := quickReply.Validate(); err != nil {
+				return prefixValidationError(fmt.Sprintf("messages[%d].quickReply", i), err)
+			}
+		}
+	}
+	return nil
+}
+
+// notificationDisabled is set for a whole request, not per message.
+// Code which adds messages to a call separately may set it more than once,
+// and the call fails rather than silently picking one of different values.
+type notificationDisabled struct {
+	disabled bool
+	isSet    bool
+	conflict bool
+}
+
+func (n *notificationDisabled) set(disabled bool) {
+	if n.isSet && n.disabled != disabled {
+		n.conflict = true
+	}
+	n.disabled = disabled
+	n.isSet = true
+}
+
 // PushMessage method
 func (client *Client) PushMessage(to string, messages ...Message) *PushMessageCall {
 	return &PushMessageCall{
@@ -38,6 +84,9 @@ type PushMessageCall struct {
 
 	to       string
 	messages []Message
+	retryKey string
+
+	notificationDisabled notificationDisabled
 }
 
 // WithContext method
@@ -46,31 +95,86 @@ func (call *PushMessageCall) WithContext(ctx context.Context) *PushMessageCall {
 	return call
 }
 
+// WithRetryKey method
+// `retryKey` is a UUID sent as X-Line-Retry-Key. The API accepts a request with
+// the same key only once, so the call can be retried without sending twice.
+func (call *PushMessageCall) WithRetryKey(retryKey string) *PushMessageCall {
+	call.retryKey = retryKey
+	return call
+}
+
+// WithNotificationDisabled method
+// See notificationDisabled for calling it more than once.
+func (call *PushMessageCall) WithNotificationDisabled(disabled bool) *PushMessageCall {
+	call.notificationDisabled.set(disabled)
+	return call
+}
+
 func (call *PushMessageCall) encodeJSON(w io.Writer) error {
-	enc := json.NewEncoder(w)
-	return enc.Encode(&struct {
-		To       string    `json:"to"`
-		Messages []Message `json:"messages"`
+	return call.c.encodeJSON(w, &struct {
+		To                   string    `json:"to"`
+		Messages             []Message `json:"messages"`
+		NotificationDisabled bool      `json:"notificationDisabled,omitempty"`
 	}{
-		To:       call.to,
-		Messages: call.messages,
+		To:                   call.to,
+		Messages:             call.c.messagesToSend(call.messages),
+		NotificationDisabled: call.notificationDisabled.disabled,
 	})
 }
 
-// Do method
-func (call *PushMessageCall) Do() (*BasicResponse, error) {
+// body validates the messages and returns the request body sent by Build and Do.
+func (call *PushMessageCall) body() ([]byte, error) {
+	if err := validateMessages(call.messages); err != nil {
+		return nil, err
+	}
+	if call.notificationDisabled.conflict {
+		return nil, ErrInconsistentNotificationDisabled
+	}
 	var buf bytes.Buffer
 	if err := call.encodeJSON(&buf); err != nil {
 		return nil, err
 	}
-	res, err := call.c.post(call.ctx, APIEndpointPushMessage, &buf)
+	return buf.Bytes(), nil
+}
+
+// Build method
+// It returns the request which Do would send first, without sending it,
+// so that the messages built by user code can be tested without a server.
+// The request has no retry key unless given by WithRetryKey, even if the
+// client is created with WithRetries.
+func (call *PushMessageCall) Build() (*http.Request, error) {
+	body, err := call.body()
+	if err != nil {
+		return nil, err
+	}
+	req, err := call.c.newMessagesRequest(APIEndpointPushMessage, body, call.retryKey)
+	if err != nil {
+		return nil, err
+	}
+	call.c.setHeaders(req)
+	return req, nil
+}
+
+// Do method
+func (call *PushMessageCall) Do() (*BasicResponse, error) {
+	body, err := call.body()
+	if err != nil {
+		return nil, err
+	}
+	call.c.checkStickers(call.messages)
+	res, attempts, err := call.c.postMessages(call.ctx, APIEndpointPushMessage, body, call.retryKey, true)
 	if res != nil && res.Body != nil {
 		defer res.Body.Close()
 	}
 	if err != nil {
 		return nil, err
 	}
-	return decodeToBasicResponse(res)
+	result, err := decodeToBasicResponse(res, call.c.codec)
+	if err != nil {
+		return nil, err
+	}
+	result.Attempts = attempts
+	return result, nil
 }
 
 // ReplyMessage method
@@ -89,6 +193,8 @@ type ReplyMessageCall struct {
 
 	replyToken string
 	messages   []Message
+
+	notificationDisabled notificationDisabled
 }
 
 // WithContext method
@@ -97,29 +203,229 @@ func (call *ReplyMessageCall) WithContext(ctx context.Context) *ReplyMessageCall
 	return call
 }
 
+// WithNotificationDisabled method
+// See notificationDisabled for calling it more than once.
+func (call *ReplyMessageCall) WithNotificationDisabled(disabled bool) *ReplyMessageCall {
+	call.notificationDisabled.set(disabled)
+	return call
+}
+
 func (call *ReplyMessageCall) encodeJSON(w io.Writer) error {
-	enc := json.NewEncoder(w)
-	return enc.Encode(&struct {
-		ReplyToken string    `json:"replyToken"`
-		Messages   []Message `json:"messages"`
+	return call.c.encodeJSON(w, &struct {
+		ReplyToken           string    `json:"replyToken"`
+		Messages             []Message `json:"messages"`
+		NotificationDisabled bool      `json:"notificationDisabled,omitempty"`
 	}{
-		ReplyToken: call.replyToken,
-		Messages:   call.messages,
+		ReplyToken:           call.replyToken,
+		Messages:             call.c.messagesToSend(call.messages),
+		NotificationDisabled: call.notificationDisabled.disabled,
 	})
 }
 
+// body validates the messages and returns the request body sent by Build and Do.
+func (call *ReplyMessageCall) body() ([]byte, error) {
+	if err := validateMessages(call.messages); err != nil {
+		return nil, err
+	}
+	if call.notificationDisabled.conflict {
+		return nil, ErrInconsistentNotificationDisabled
+	}
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Build method
+// It returns the request which Do would send first, without sending it,
+// so that the messages built by user code can be tested without a server.
+func (call *ReplyMessageCall) Build() (*http.Request, error) {
+	body, err := call.body()
+	if err != nil {
+		return nil, err
+	}
+	req, err := call.c.newMessagesRequest(APIEndpointReplyMessage, body, "")
+	if err != nil {
+		return nil, err
+	}
+	call.c.setHeaders(req)
+	return req, nil
+}
+
 // Do method
 func (call *ReplyMessageCall) Do() (*BasicResponse, error) {
+	body, err := call.body()
+	if err != nil {
+		return nil, err
+	}
+	call.c.checkStickers(call.messages)
+	call.c.trackReplyToken(call.replyToken)
+	res, attempts, err := call.c.postMessages(call.ctx, APIEndpointReplyMessage, body, "", false)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	result, err := decodeToBasicResponse(res, call.c.codec)
+	if err != nil {
+		return nil, err
+	}
+	result.Attempts = attempts
+	return result, nil
+}
+
+// Multicast method
+func (client *Client) Multicast(to []string, messages ...Message) *MulticastCall {
+	return &MulticastCall{
+		c:        client,
+		to:       to,
+		messages: messages,
+	}
+}
+
+// MulticastCall type
+type MulticastCall struct {
+	c   *Client
+	ctx context.Context
+
+	to       []string
+	messages []Message
+	retryKey string
+
+	notificationDisabled notificationDisabled
+}
+
+// WithContext method
+func (call *MulticastCall) WithContext(ctx context.Context) *MulticastCall {
+	call.ctx = ctx
+	return call
+}
+
+// WithRetryKey method
+// `retryKey` is a UUID sent as X-Line-Retry-Key. See PushMessageCall.WithRetryKey.
+func (call *MulticastCall) WithRetryKey(retryKey string) *MulticastCall {
+	call.retryKey = retryKey
+	return call
+}
+
+// WithNotificationDisabled method
+// See notificationDisabled for calling it more than once.
+func (call *MulticastCall) WithNotificationDisabled(disabled bool) *MulticastCall {
+	call.notificationDisabled.set(disabled)
+	return call
+}
+
+func (call *MulticastCall) encodeJSON(w io.Writer) error {
+	return call.c.encodeJSON(w, &struct {
+		To                   []string  `json:"to"`
+		Messages             []Message `json:"messages"`
+		NotificationDisabled bool      `json:"notificationDisabled,omitempty"`
+	}{
+		To:                   call.to,
+		Messages:             call.c.messagesToSend(call.messages),
+		NotificationDisabled: call.notificationDisabled.disabled,
+	})
+}
+
+// body validates the messages and returns the request body sent by Build and Do.
+func (call *MulticastCall) body() ([]byte, error) {
+	if err := validateMessages(call.messages); err != nil {
+		return nil, err
+	}
+	if call.notificationDisabled.conflict {
+		return nil, ErrInconsistentNotificationDisabled
+	}
 	var buf bytes.Buffer
 	if err := call.encodeJSON(&buf); err != nil {
 		return nil, err
 	}
-	res, err := call.c.post(call.ctx, APIEndpointReplyMessage, &buf)
+	return buf.Bytes(), nil
+}
+
+// Build method
+// It returns the request which Do would send first, without sending it,
+// so that the messages built by user code can be tested without a server.
+// The request has no retry key unless given by WithRetryKey, even if the
+// client is created with WithRetries.
+func (call *MulticastCall) Build() (*http.Request, error) {
+	body, err := call.body()
+	if err != nil {
+		return nil, err
+	}
+	req, err := call.c.newMessagesRequest(APIEndpointMulticast, body, call.retryKey)
+	if err != nil {
+		return nil, err
+	}
+	call.c.setHeaders(req)
+	return req, nil
+}
+
+// Do method
+func (call *MulticastCall) Do() (*BasicResponse, error) {
+	body, err := call.body()
+	if err != nil {
+		return nil, err
+	}
+	call.c.checkStickers(call.messages)
+	res, attempts, err := call.c.postMessages(call.ctx, APIEndpointMulticast, body, call.retryKey, true)
 	if res != nil && res.Body != nil {
 		defer res.Body.Close()
 	}
 	if err != nil {
 		return nil, err
 	}
-	return decodeToBasicResponse(res)
+	result, err := decodeToBasicResponse(res, call.c.codec)
+	if err != nil {
+		return nil, err
+	}
+	result.Attempts = attempts
+	return result, nil
+}
+
+// ReplyThenPush method
+// The returned call replies to the event with `loading` right away,
+// then runs `slowWork` and pushes its result to the event source.
+// ErrNoEventSource is returned without replying if the event has no source.
+func (client *Client) ReplyThenPush(event *Event, loading Message, slowWork func() []Message) *ReplyThenPushCall {
+	return &ReplyThenPushCall{
+		c:        client,
+		event:    event,
+		loading:  loading,
+		slowWork: slowWork,
+	}
+}
+
+// ReplyThenPushCall type
+type ReplyThenPushCall struct {
+	c   *Client
+	ctx context.Context
+
+	event    *Event
+	loading  Message
+	slowWork func() []Message
+}
+
+// WithContext method
+func (call *ReplyThenPushCall) WithContext(ctx context.Context) *ReplyThenPushCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *ReplyThenPushCall) Do() error {
+	to := ChatIDFromSource(call.event.Source)
+	if to == "" {
+		return ErrNoEventSource
+	}
+	if _, err := call.c.ReplyMessage(call.event.ReplyToken, call.loading).WithContext(call.ctx).Do(); err != nil {
+		return err
+	}
+	messages := call.slowWork()
+	if len(messages) == 0 {
+		return nil
+	}
+	_, err := call.c.PushMessage(to, messages...).WithContext(call.ctx).Do()
+	return err
 }
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,7 +46,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -55,7 +56,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"image","originalContentUrl":"http://example.com/original.jpg","previewImageUrl":"http://example.com/preview.jpg"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -65,7 +66,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"video","originalContentUrl":"http://example.com/original.mp4","previewImageUrl":"http://example.com/preview.jpg"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -75,7 +76,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"audio","originalContentUrl":"http://example.com/original.m4a","duration":1000}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -85,7 +86,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"location","title":"title","address":"address","latitude":35.65910807942215,"longitude":139.70372892916203}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -95,7 +96,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"sticker","packageId":"1","stickerId":"1"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -117,7 +118,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"template","altText":"this is a buttons template","template":{"type":"buttons","thumbnailImageUrl":"https://example.com/bot/images/image.jpg","title":"Menu","text":"Please select","actions":[{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123"},{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123","text":"text"},{"type":"uri","label":"View detail","uri":"http://example.com/page/123"}]}}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -139,7 +140,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"template","altText":"this is a buttons template","template":{"type":"buttons","title":"Menu","text":"Please select","actions":[{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123"},{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123","text":"text"},{"type":"uri","label":"View detail","uri":"http://example.com/page/123"}]}}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -161,7 +162,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"template","altText":"this is a buttons template","template":{"type":"buttons","thumbnailImageUrl":"https://example.com/bot/images/image.jpg","text":"Please select","actions":[{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123"},{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123","text":"text"},{"type":"uri","label":"View detail","uri":"http://example.com/page/123"}]}}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -183,7 +184,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"template","altText":"this is a buttons template","template":{"type":"buttons","text":"Please select","actions":[{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123"},{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=123","text":"text"},{"type":"uri","label":"View detail","uri":"http://example.com/page/123"}]}}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -202,7 +203,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"template","altText":"this is a confirm template","template":{"type":"confirm","text":"Are you sure?","actions":[{"type":"message","label":"Yes","text":"yes"},{"type":"message","label":"No","text":"no"}]}}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -226,7 +227,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"template","altText":"this is a carousel template","template":{"type":"carousel","columns":[{"thumbnailImageUrl":"https://example.com/bot/images/item1.jpg","title":"this is menu","text":"description","actions":[{"type":"postback","label":"Buy","data":"action=buy\u0026itemid=111"},{"type":"postback","label":"Add to cart","data":"action=add\u0026itemid=111"},{"type":"uri","label":"View detail","uri":"http://example.com/page/111"}]}]}}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -244,7 +245,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"imagemap","baseUrl":"https://example.com/bot/images/rm001","altText":"this is an imagemap","baseSize":{"width":1040,"height":1040},"actions":[{"type":"uri","linkUri":"https://example.com/","area":{"x":520,"y":0,"width":520,"height":1040}},{"type":"message","text":"hello","area":{"x":520,"y":0,"width":520,"height":1040}}]}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -254,7 +255,7 @@ func TestPushMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"Hello, world1"},{"type":"text","text":"Hello, world2"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -268,7 +269,7 @@ func TestPushMessages(t *testing.T) {
 					Code: 400,
 					Response: &ErrorResponse{
 						Message: "Request body has 2 error(s).",
-						Details: []errorResponseDetail{
+						Details: []ErrorDetail{
 							{
 								Message:  "may not be empty",
 								Property: "messages[0].text",
@@ -323,7 +324,7 @@ func TestPushMessages(t *testing.T) {
 		}
 		if tc.Want.Response != nil {
 			if !reflect.DeepEqual(res, tc.Want.Response) {
-				t.Errorf("Response %d %q; want %q", i, res, tc.Want.Response)
+				t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
 			}
 		}
 	}
@@ -348,6 +349,300 @@ func TestPushMessagesWithContext(t *testing.T) {
 	}
 }
 
+func TestPushMessagesValidation(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		t.Error("request should not be sent")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.PushMessage(
+		"U0cc15697597f61dd8b01cea8b027050e",
+		NewTextMessage("Hello, world"),
+		NewTextMessage("Hello, $").AddEmoji(NewEmoji(8, "5ac1bfd5040ab15980c9b435", "001")),
+	).Do()
+	want := &ValidationError{
+		Field:  "messages[1].emojis[0].index",
+		Reason: "must be within the text length 8",
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("err %v; want %v", err, want)
+	}
+
+	_, err = client.PushMessage(
+		"U0cc15697597f61dd8b01cea8b027050e",
+		NewTextMessage("Hello, world"),
+		NewTextMessage(strings.Repeat("a", 5001)),
+	).Do()
+	want = &ValidationError{
+		Field:  "messages[1].text",
+		Reason: "must be at most 5000 characters",
+		Err:    ErrTextTooLong,
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("err %v; want %v", err, want)
+	}
+}
+
+func TestWithRetryKey(t *testing.T) {
+	const retryKey = "123e4567-e89b-12d3-a456-426614174000"
+	var gotRetryKeys []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		gotRetryKeys = append(gotRetryKeys, r.Header.Get("X-Line-Retry-Key"))
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).WithRetryKey(retryKey).Do(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Multicast([]string{"U0cc15697597f61dd8b01cea8b027050e"}, NewTextMessage("Hello, world")).WithRetryKey(retryKey).Do(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).Do(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{retryKey, retryKey, ""}
+	if !reflect.DeepEqual(gotRetryKeys, want) {
+		t.Errorf("X-Line-Retry-Key %v; want %v", gotRetryKeys, want)
+	}
+}
+
+func TestRetryKeyEcho(t *testing.T) {
+	const retryKey = "123e4567-e89b-12d3-a456-426614174000"
+	var echo bool
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if echo {
+			w.Header().Set("X-Line-Retry-Key", r.Header.Get("X-Line-Retry-Key"))
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, echo = range []bool{true, false} {
+		res, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).WithRetryKey(retryKey).Do()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &BasicResponse{Attempts: 1}
+		if echo {
+			want.RetryKey = retryKey
+		}
+		if !reflect.DeepEqual(res, want) {
+			t.Errorf("Response %v; want %v (echo %t)", res, want, echo)
+		}
+	}
+}
+
+func TestNotificationDisabled(t *testing.T) {
+	var testCases = []struct {
+		Call        func(*Client) (*BasicResponse, error)
+		RequestBody []byte
+		Error       error
+	}{
+		{
+			Call: func(c *Client) (*BasicResponse, error) {
+				return c.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).WithNotificationDisabled(true).Do()
+			},
+			RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"Hello, world"}],"notificationDisabled":true}` + "\n"),
+		},
+		{
+			// Set twice to the same value
+			Call: func(c *Client) (*BasicResponse, error) {
+				return c.Multicast([]string{"U0cc15697597f61dd8b01cea8b027050e"}, NewTextMessage("Hello, world")).WithNotificationDisabled(true).WithNotificationDisabled(true).Do()
+			},
+			RequestBody: []byte(`{"to":["U0cc15697597f61dd8b01cea8b027050e"],"messages":[{"type":"text","text":"Hello, world"}],"notificationDisabled":true}` + "\n"),
+		},
+		{
+			Call: func(c *Client) (*BasicResponse, error) {
+				return c.ReplyMessage("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", NewTextMessage("Hello, world")).WithNotificationDisabled(false).Do()
+			},
+			RequestBody: []byte(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
+		},
+		{
+			// Set to different values
+			Call: func(c *Client) (*BasicResponse, error) {
+				return c.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).WithNotificationDisabled(true).WithNotificationDisabled(false).Do()
+			},
+			Error: ErrInconsistentNotificationDisabled,
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if tc.Error != nil {
+			t.Errorf("Test %d sent a request; want %v", currentTestIdx, tc.Error)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.RequestBody) {
+			t.Errorf("RequestBody %d %s; want %s", currentTestIdx, body, tc.RequestBody)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		if _, err := tc.Call(client); err != tc.Error {
+			t.Errorf("Error %d %v; want %v", i, err, tc.Error)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	client, err := New("testsecret", "testtoken", WithEndpointBase("https://example.com/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testCases = []struct {
+		Build    func() (*http.Request, error)
+		URL      string
+		RetryKey string
+		Body     []byte
+	}{
+		{
+			Build: func() (*http.Request, error) {
+				return client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world")).Build()
+			},
+			URL:  "https://example.com/v2/bot/message/push",
+			Body: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
+		},
+		{
+			Build: func() (*http.Request, error) {
+				return client.ReplyMessage("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", NewStickerMessage("446", "1988")).Build()
+			},
+			URL:  "https://example.com/v2/bot/message/reply",
+			Body: []byte(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"sticker","packageId":"446","stickerId":"1988"}]}` + "\n"),
+		},
+		{
+			Build: func() (*http.Request, error) {
+				return client.Multicast([]string{"U0cc15697597f61dd8b01cea8b027050e"}, NewTextMessage("Hello, world")).WithRetryKey("123e4567-e89b-12d3-a456-426614174000").Build()
+			},
+			URL:      "https://example.com/v2/bot/message/multicast",
+			RetryKey: "123e4567-e89b-12d3-a456-426614174000",
+			Body:     []byte(`{"to":["U0cc15697597f61dd8b01cea8b027050e"],"messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
+		},
+	}
+	for i, tc := range testCases {
+		req, err := tc.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req.Method != http.MethodPost {
+			t.Errorf("Method %d %s; want %s", i, req.Method, http.MethodPost)
+		}
+		if req.URL.String() != tc.URL {
+			t.Errorf("URL %d %s; want %s", i, req.URL, tc.URL)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer testtoken" {
+			t.Errorf("Authorization %d %s; want Bearer testtoken", i, got)
+		}
+		if got := req.Header.Get("X-Line-Retry-Key"); got != tc.RetryKey {
+			t.Errorf("X-Line-Retry-Key %d %s; want %s", i, got, tc.RetryKey)
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.Body) {
+			t.Errorf("Body %d %s; want %s", i, body, tc.Body)
+		}
+	}
+
+	// Invalid messages
+	_, err = client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", NewTextMessage(strings.Repeat("a", 5001))).Build()
+	if verr, ok := err.(*ValidationError); !ok || verr.Field != "messages[0].text" || verr.Err != ErrTextTooLong {
+		t.Errorf("Error %v; want ErrTextTooLong of messages[0].text", err)
+	}
+}
+
+func TestTooManyMessages(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		t.Error("request should not be sent")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := make([]Message, 6)
+	for i := range messages {
+		messages[i] = NewTextMessage("Hello, world")
+	}
+	if _, err := client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", messages...).Do(); err != ErrTooManyMessages {
+		t.Errorf("PushMessage err %v; want %v", err, ErrTooManyMessages)
+	}
+	if _, err := client.ReplyMessage("nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", messages...).Do(); err != ErrTooManyMessages {
+		t.Errorf("ReplyMessage err %v; want %v", err, ErrTooManyMessages)
+	}
+}
+
+func TestPushMessagesAutoAltText(t *testing.T) {
+	var gotBody []byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		gotBody, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WithAutoAltText()(client); err != nil {
+		t.Fatal(err)
+	}
+	flex := NewFlexMessage("", &BubbleContainer{
+		Body: &BoxComponent{
+			Layout: FlexBoxLayoutTypeVertical,
+			Contents: []FlexComponent{
+				&SeparatorComponent{},
+				&TextComponent{Text: "Hello, world"},
+			},
+		},
+	})
+	template := NewTemplateMessage("", NewConfirmTemplate(
+		"Are you sure?",
+		NewMessageTemplateAction("Yes", "yes"),
+		NewMessageTemplateAction("No", "no"),
+	))
+	_, err = client.PushMessage("U0cc15697597f61dd8b01cea8b027050e", flex, template).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[` +
+		`{"type":"flex","altText":"Hello, world","contents":{"type":"bubble","body":{"type":"box","layout":"vertical","contents":[{"type":"separator"},{"type":"text","text":"Hello, world"}]}}},` +
+		`{"type":"template","altText":"Are you sure?","template":{"type":"confirm","text":"Are you sure?","actions":[{"type":"message","label":"Yes","text":"yes"},{"type":"message","label":"No","text":"no"}]}}]}` + "\n"
+	if string(gotBody) != want {
+		t.Errorf("RequestBody %s; want %s", gotBody, want)
+	}
+	if flex.AltText != "" || template.AltText != "" {
+		t.Errorf("AltText of the given messages is modified")
+	}
+}
+
 func TestReplyMessages(t *testing.T) {
 	var replyToken = "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA"
 	type want struct {
@@ -368,7 +663,7 @@ func TestReplyMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -378,7 +673,7 @@ func TestReplyMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"location","title":"title","address":"address","latitude":35.65910807942215,"longitude":139.70372892916203}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -388,7 +683,7 @@ func TestReplyMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"image","originalContentUrl":"http://example.com/original.jpg","previewImageUrl":"http://example.com/preview.jpg"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -398,7 +693,7 @@ func TestReplyMessages(t *testing.T) {
 			Response:     []byte(`{}`),
 			Want: want{
 				RequestBody: []byte(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"sticker","packageId":"1","stickerId":"1"}]}` + "\n"),
-				Response:    &BasicResponse{},
+				Response:    &BasicResponse{Attempts: 1},
 			},
 		},
 		{
@@ -412,7 +707,7 @@ func TestReplyMessages(t *testing.T) {
 					Code: 400,
 					Response: &ErrorResponse{
 						Message: "Request body has 2 error(s).",
-						Details: []errorResponseDetail{
+						Details: []ErrorDetail{
 							{
 								Message:  "may not be empty",
 								Property: "messages[0].text",
@@ -467,7 +762,7 @@ func TestReplyMessages(t *testing.T) {
 		}
 		if tc.Want.Response != nil {
 			if !reflect.DeepEqual(res, tc.Want.Response) {
-				t.Errorf("Response %d %q; want %q", i, res, tc.Want.Response)
+				t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
 			}
 		}
 	}
@@ -492,6 +787,76 @@ func TestReplyMessagesWithContext(t *testing.T) {
 	}
 }
 
+func TestReplyThenPush(t *testing.T) {
+	event := &Event{
+		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+		Type:       EventTypeMessage,
+		Source: &EventSource{
+			Type:   EventSourceTypeUser,
+			UserID: "U0cc15697597f61dd8b01cea8b027050e",
+		},
+	}
+	var got []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		got = append(got, r.URL.Path+" "+string(body))
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.ReplyThenPush(event, NewTextMessage("Loading..."), func() []Message {
+		if len(got) != 1 {
+			t.Errorf("slowWork is called after %d requests; want 1", len(got))
+		}
+		return []Message{NewTextMessage("Done")}
+	}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		APIEndpointReplyMessage + ` {"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"text","text":"Loading..."}]}` + "\n",
+		APIEndpointPushMessage + ` {"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"Done"}]}` + "\n",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Requests %q; want %q", got, want)
+	}
+}
+
+func TestReplyThenPushWithoutSource(t *testing.T) {
+	event := &Event{
+		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+		Type:       EventTypeMessage,
+	}
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.ReplyThenPush(event, NewTextMessage("Loading..."), func() []Message {
+		t.Error("slowWork is called")
+		return nil
+	}).Do()
+	if err != ErrNoEventSource {
+		t.Errorf("Error %v; want %v", err, ErrNoEventSource)
+	}
+	if requests != 0 {
+		t.Errorf("requests %d; want 0", requests)
+	}
+}
+
 func BenchmarkPushMessages(b *testing.B) {
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
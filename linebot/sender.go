@@ -0,0 +1,49 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"golang.org/x/net/context"
+)
+
+// Sender interface
+// Sender is the send path of the Client. Code which only sends messages can
+// depend on it, and its tests can use an in-memory implementation instead of
+// an HTTP server.
+type Sender interface {
+	PushMessages(ctx context.Context, to string, messages ...Message) error
+	ReplyMessages(ctx context.Context, replyToken string, messages ...Message) error
+	MulticastMessages(ctx context.Context, to []string, messages ...Message) error
+}
+
+var _ Sender = (*Client)(nil)
+
+// PushMessages method
+func (client *Client) PushMessages(ctx context.Context, to string, messages ...Message) error {
+	_, err := client.PushMessage(to, messages...).WithContext(ctx).Do()
+	return err
+}
+
+// ReplyMessages method
+func (client *Client) ReplyMessages(ctx context.Context, replyToken string, messages ...Message) error {
+	_, err := client.ReplyMessage(replyToken, messages...).WithContext(ctx).Do()
+	return err
+}
+
+// MulticastMessages method
+func (client *Client) MulticastMessages(ctx context.Context, to []string, messages ...Message) error {
+	_, err := client.Multicast(to, messages...).WithContext(ctx).Do()
+	return err
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+type sentMessages struct {
+	Method   string
+	To       []string
+	Messages []Message
+}
+
+// fakeSender is an in-memory Sender capturing the sent messages.
+type fakeSender struct {
+	sent []sentMessages
+}
+
+func (s *fakeSender) PushMessages(ctx context.Context, to string, messages ...Message) error {
+	s.sent = append(s.sent, sentMessages{"push", []string{to}, messages})
+	return nil
+}
+
+func (s *fakeSender) ReplyMessages(ctx context.Context, replyToken string, messages ...Message) error {
+	s.sent = append(s.sent, sentMessages{"reply", []string{replyToken}, messages})
+	return nil
+}
+
+func (s *fakeSender) MulticastMessages(ctx context.Context, to []string, messages ...Message) error {
+	s.sent = append(s.sent, sentMessages{"multicast", to, messages})
+	return nil
+}
+
+func TestFakeSender(t *testing.T) {
+	// welcome is the kind of code a bot would test with a fake Sender
+	welcome := func(s Sender, event *Event, admins []string) error {
+		if err := s.ReplyMessages(context.Background(), event.ReplyToken, NewTextMessage("Welcome!")); err != nil {
+			return err
+		}
+		return s.MulticastMessages(context.Background(), admins, NewTextMessage("New friend: "+event.Source.UserID))
+	}
+	sender := &fakeSender{}
+	event := &Event{
+		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+		Type:       EventTypeFollow,
+		Source: &EventSource{
+			Type:   EventSourceTypeUser,
+			UserID: "U206d25c2ea6bd87c17655609a1c37cb8",
+		},
+	}
+	admins := []string{"U0cc15697597f61dd8b01cea8b027050e", "U4af4980629d5c29a56bc36a0f0d8c53a"}
+	if err := welcome(sender, event, admins); err != nil {
+		t.Fatal(err)
+	}
+	want := []sentMessages{
+		{"reply", []string{"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA"}, []Message{NewTextMessage("Welcome!")}},
+		{"multicast", admins, []Message{NewTextMessage("New friend: U206d25c2ea6bd87c17655609a1c37cb8")}},
+	}
+	if !reflect.DeepEqual(sender.sent, want) {
+		t.Errorf("sent %v; want %v", sender.sent, want)
+	}
+}
+
+func TestClientSender(t *testing.T) {
+	type want struct {
+		URLPath     string
+		RequestBody []byte
+	}
+	var testCases = []struct {
+		Send func(Sender) error
+		Want want
+	}{
+		{
+			Send: func(s Sender) error {
+				return s.PushMessages(context.Background(), "U0cc15697597f61dd8b01cea8b027050e", NewTextMessage("Hello, world"))
+			},
+			Want: want{
+				URLPath:     APIEndpointPushMessage,
+				RequestBody: []byte(`{"to":"U0cc15697597f61dd8b01cea8b027050e","messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
+			},
+		},
+		{
+			Send: func(s Sender) error {
+				return s.ReplyMessages(context.Background(), "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA", NewTextMessage("Hello, world"))
+			},
+			Want: want{
+				URLPath:     APIEndpointReplyMessage,
+				RequestBody: []byte(`{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
+			},
+		},
+		{
+			Send: func(s Sender) error {
+				return s.MulticastMessages(context.Background(), []string{"U0cc15697597f61dd8b01cea8b027050e", "U4af4980629d5c29a56bc36a0f0d8c53a"}, NewTextMessage("Hello, world"))
+			},
+			Want: want{
+				URLPath:     APIEndpointMulticast,
+				RequestBody: []byte(`{"to":["U0cc15697597f61dd8b01cea8b027050e","U4af4980629d5c29a56bc36a0f0d8c53a"],"messages":[{"type":"text","text":"Hello, world"}]}` + "\n"),
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != http.MethodPost {
+			t.Errorf("Method %s; want %s", r.Method, http.MethodPost)
+		}
+		if r.URL.Path != tc.Want.URLPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, tc.Want.URLPath)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.Want.RequestBody) {
+			t.Errorf("RequestBody %s; want %s", body, tc.Want.RequestBody)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		if err := tc.Send(client); err != nil {
+			t.Errorf("Test %d %v", i, err)
+		}
+	}
+}
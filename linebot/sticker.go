@@ -0,0 +1,87 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"strconv"
+)
+
+// stickerRange is a range of sticker IDs in a sticker package
+type stickerRange struct {
+	packageID int
+	first     int
+	last      int
+}
+
+// sendableStickers are the stickers which bots can send, as listed in
+// https://developers.line.biz/en/docs/messaging-api/sticker-list/
+var sendableStickers = []stickerRange{
+	{446, 1988, 2027},
+	{789, 10855, 10894},
+	{1070, 17839, 17878},
+	{6136, 10551376, 10551399},
+	{6325, 10979904, 10979927},
+	{6359, 11069848, 11069871},
+	{6362, 11087920, 11087943},
+	{6370, 11088016, 11088039},
+	{6632, 11825374, 11825397},
+	{8515, 16581242, 16581265},
+	{8522, 16581266, 16581289},
+	{8525, 16581290, 16581313},
+	{11537, 52002734, 52002773},
+	{11538, 51626494, 51626533},
+	{11539, 52114110, 52114149},
+}
+
+// WithStickerCheck function
+// Once set, the client warns through the logger when a sticker message has
+// a package ID or a sticker ID which bots cannot send. The message is still
+// sent, since the list of sendable stickers may grow.
+func WithStickerCheck() ClientOption {
+	return func(client *Client) error {
+		client.stickerCheck = true
+		return nil
+	}
+}
+
+// IsSendableSticker function
+// It reports whether the sticker is in the documented ranges of stickers which bots can send.
+func IsSendableSticker(packageID, stickerID string) bool {
+	p, err := strconv.Atoi(packageID)
+	if err != nil {
+		return false
+	}
+	s, err := strconv.Atoi(stickerID)
+	if err != nil {
+		return false
+	}
+	for _, r := range sendableStickers {
+		if r.packageID == p {
+			return s >= r.first && s <= r.last
+		}
+	}
+	return false
+}
+
+func (client *Client) checkStickers(messages []Message) {
+	if !client.stickerCheck || client.logger == nil {
+		return
+	}
+	for i, m := range messages {
+		if m, ok := m.(*StickerMessage); ok && !IsSendableSticker(m.PackageID, m.StickerID) {
+			client.logger.Printf("linebot: messages[%d] has sticker %s of package %s, which is not in the sendable sticker list", i, m.StickerID, m.PackageID)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsSendableSticker(t *testing.T) {
+	var testCases = []struct {
+		PackageID string
+		StickerID string
+		Want      bool
+	}{
+		{PackageID: "446", StickerID: "1988", Want: true},
+		{PackageID: "446", StickerID: "2027", Want: true},
+		{PackageID: "11537", StickerID: "52002734", Want: true},
+		{PackageID: "446", StickerID: "2028", Want: false},
+		{PackageID: "446", StickerID: "10855", Want: false},
+		{PackageID: "1", StickerID: "1", Want: false},
+		{PackageID: "abc", StickerID: "1988", Want: false},
+		{PackageID: "446", StickerID: "", Want: false},
+	}
+	for i, tc := range testCases {
+		if got := IsSendableSticker(tc.PackageID, tc.StickerID); got != tc.Want {
+			t.Errorf("IsSendableSticker %d %t; want %t", i, got, tc.Want)
+		}
+	}
+}
+
+func TestStickerCheck(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := &testLogger{}
+	for _, option := range []ClientOption{WithLogger(logger), WithStickerCheck()} {
+		if err := option(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = client.PushMessage(
+		"U0cc15697597f61dd8b01cea8b027050e",
+		NewStickerMessage("446", "1988"),
+		NewStickerMessage("446", "9999"),
+	).Do()
+	if err != nil {
+		t.Fatalf("out of range stickers must not be an error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests %d; want 1", requests)
+	}
+	var warnings []string
+	for _, line := range logger.lines {
+		if strings.Contains(line, "sendable sticker") {
+			warnings = append(warnings, line)
+		}
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "messages[1] has sticker 9999 of package 446") {
+		t.Errorf("warnings %q; want one for messages[1]", warnings)
+	}
+}
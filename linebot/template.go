@@ -16,6 +16,7 @@ package linebot
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 // TemplateType type
@@ -33,11 +34,28 @@ type TemplateActionType string
 
 // TemplateActionType constants
 const (
-	TemplateActionTypeURI      TemplateActionType = "uri"
-	TemplateActionTypeMessage  TemplateActionType = "message"
-	TemplateActionTypePostback TemplateActionType = "postback"
+	TemplateActionTypeURI            TemplateActionType = "uri"
+	TemplateActionTypeMessage        TemplateActionType = "message"
+	TemplateActionTypePostback       TemplateActionType = "postback"
+	TemplateActionTypeDatetimePicker TemplateActionType = "datetimepicker"
+	TemplateActionTypeCamera         TemplateActionType = "camera"
+	TemplateActionTypeCameraRoll     TemplateActionType = "cameraRoll"
+	TemplateActionTypeLocation       TemplateActionType = "location"
 )
 
+// DatetimePickerMode type
+type DatetimePickerMode string
+
+// DatetimePickerMode constants
+const (
+	DatetimePickerModeDate     DatetimePickerMode = "date"
+	DatetimePickerModeTime     DatetimePickerMode = "time"
+	DatetimePickerModeDatetime DatetimePickerMode = "datetime"
+)
+
+// maxPostbackDataLength is the maximum number of bytes in postback data
+const maxPostbackDataLength = 300
+
 // Template interface
 type Template interface {
 	json.Marshaler
@@ -162,68 +180,222 @@ type TemplateAction interface {
 
 // URITemplateAction type
 type URITemplateAction struct {
-	Label string
-	URI   string
+	Label         string
+	URI           string
+	AltURIDesktop string
 }
 
 // MarshalJSON method of URITemplateAction
 func (a *URITemplateAction) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&struct {
-		Type  TemplateActionType `json:"type"`
-		Label string             `json:"label"`
-		URI   string             `json:"uri"`
+	type altURI struct {
+		Desktop string `json:"desktop"`
+	}
+	raw := &struct {
+		Type   TemplateActionType `json:"type"`
+		Label  string             `json:"label"`
+		URI    string             `json:"uri"`
+		AltURI *altURI            `json:"altUri,omitempty"`
 	}{
 		Type:  TemplateActionTypeURI,
 		Label: a.Label,
 		URI:   a.URI,
-	})
+	}
+	if a.AltURIDesktop != "" {
+		raw.AltURI = &altURI{Desktop: a.AltURIDesktop}
+	}
+	return json.Marshal(raw)
 }
 
 // MessageTemplateAction type
 type MessageTemplateAction struct {
-	Label string
-	Text  string
+	Label       string
+	Text        string
+	DisplayText string
 }
 
 // MarshalJSON method of MessageTemplateAction
 func (a *MessageTemplateAction) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Type  TemplateActionType `json:"type"`
-		Label string             `json:"label"`
-		Text  string             `json:"text"`
+		Type        TemplateActionType `json:"type"`
+		Label       string             `json:"label"`
+		Text        string             `json:"text"`
+		DisplayText string             `json:"displayText,omitempty"`
 	}{
-		Type:  TemplateActionTypeMessage,
-		Label: a.Label,
-		Text:  a.Text,
+		Type:        TemplateActionTypeMessage,
+		Label:       a.Label,
+		Text:        a.Text,
+		DisplayText: a.DisplayText,
 	})
 }
 
+// WithDisplayText method of MessageTemplateAction
+func (a *MessageTemplateAction) WithDisplayText(displayText string) *MessageTemplateAction {
+	a.DisplayText = displayText
+	return a
+}
+
 // PostbackTemplateAction type
+// `Text` and `DisplayText` cannot be used together. `Text` is ignored when `DisplayText` is set.
 type PostbackTemplateAction struct {
-	Label string
-	Data  string
-	Text  string
+	Label       string
+	Data        string
+	Text        string
+	DisplayText string
 }
 
 // MarshalJSON method of PostbackTemplateAction
 func (a *PostbackTemplateAction) MarshalJSON() ([]byte, error) {
+	raw := &struct {
+		Type        TemplateActionType `json:"type"`
+		Label       string             `json:"label"`
+		Data        string             `json:"data"`
+		Text        string             `json:"text,omitempty"`
+		DisplayText string             `json:"displayText,omitempty"`
+	}{
+		Type:        TemplateActionTypePostback,
+		Label:       a.Label,
+		Data:        a.Data,
+		Text:        a.Text,
+		DisplayText: a.DisplayText,
+	}
+	if raw.DisplayText != "" {
+		raw.Text = ""
+	}
+	return json.Marshal(raw)
+}
+
+// Validate method of PostbackTemplateAction
+func (a *PostbackTemplateAction) Validate() error {
+	return validatePostbackData(a.Data)
+}
+
+// WithDisplayText method of PostbackTemplateAction
+// The text is displayed in the chat as a message from the user instead of `Text`.
+func (a *PostbackTemplateAction) WithDisplayText(displayText string) *PostbackTemplateAction {
+	a.DisplayText = displayText
+	a.Text = ""
+	return a
+}
+
+// DatetimePickerTemplateAction type
+type DatetimePickerTemplateAction struct {
+	Label   string
+	Data    string
+	Mode    DatetimePickerMode
+	Initial string
+	Max     string
+	Min     string
+}
+
+// MarshalJSON method of DatetimePickerTemplateAction
+func (a *DatetimePickerTemplateAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type    TemplateActionType `json:"type"`
+		Label   string             `json:"label"`
+		Data    string             `json:"data"`
+		Mode    DatetimePickerMode `json:"mode"`
+		Initial string             `json:"initial,omitempty"`
+		Max     string             `json:"max,omitempty"`
+		Min     string             `json:"min,omitempty"`
+	}{
+		Type:    TemplateActionTypeDatetimePicker,
+		Label:   a.Label,
+		Data:    a.Data,
+		Mode:    a.Mode,
+		Initial: a.Initial,
+		Max:     a.Max,
+		Min:     a.Min,
+	})
+}
+
+// CameraTemplateAction type
+// It can only be used in quick reply buttons.
+type CameraTemplateAction struct {
+	Label string
+}
+
+// MarshalJSON method of CameraTemplateAction
+func (a *CameraTemplateAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type  TemplateActionType `json:"type"`
+		Label string             `json:"label"`
+	}{
+		Type:  TemplateActionTypeCamera,
+		Label: a.Label,
+	})
+}
+
+// CameraRollTemplateAction type
+// It can only be used in quick reply buttons.
+type CameraRollTemplateAction struct {
+	Label string
+}
+
+// MarshalJSON method of CameraRollTemplateAction
+func (a *CameraRollTemplateAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type  TemplateActionType `json:"type"`
+		Label string             `json:"label"`
+	}{
+		Type:  TemplateActionTypeCameraRoll,
+		Label: a.Label,
+	})
+}
+
+// LocationTemplateAction type
+// It can only be used in quick reply buttons.
+type LocationTemplateAction struct {
+	Label string
+}
+
+// MarshalJSON method of LocationTemplateAction
+func (a *LocationTemplateAction) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		Type  TemplateActionType `json:"type"`
 		Label string             `json:"label"`
-		Data  string             `json:"data"`
-		Text  string             `json:"text,omitempty"`
 	}{
-		Type:  TemplateActionTypePostback,
+		Type:  TemplateActionTypeLocation,
 		Label: a.Label,
-		Data:  a.Data,
-		Text:  a.Text,
 	})
 }
 
+// Validate method of DatetimePickerTemplateAction
+func (a *DatetimePickerTemplateAction) Validate() error {
+	return validatePostbackData(a.Data)
+}
+
+func validatePostbackData(data string) error {
+	if len(data) > maxPostbackDataLength {
+		return &ValidationError{
+			Field:  "data",
+			Reason: fmt.Sprintf("must be at most %d bytes", maxPostbackDataLength),
+		}
+	}
+	return nil
+}
+
+// validateActions reports the first invalid action as "<field>[i].<property>".
+func validateActions(field string, actions []TemplateAction) error {
+	for i, a := range actions {
+		v, ok := a.(validator)
+		if !ok {
+			continue
+		}
+		if err := v.Validate(); err != nil {
+			return prefixValidationError(fmt.Sprintf("%s[%d]", field, i), err)
+		}
+	}
+	return nil
+}
+
 // implements TemplateAction interface
-func (*URITemplateAction) templateAction()      {}
-func (*MessageTemplateAction) templateAction()  {}
-func (*PostbackTemplateAction) templateAction() {}
+func (*URITemplateAction) templateAction()            {}
+func (*MessageTemplateAction) templateAction()        {}
+func (*PostbackTemplateAction) templateAction()       {}
+func (*DatetimePickerTemplateAction) templateAction() {}
+func (*CameraTemplateAction) templateAction()         {}
+func (*CameraRollTemplateAction) templateAction()     {}
+func (*LocationTemplateAction) templateAction()       {}
 
 // NewURITemplateAction function
 func NewURITemplateAction(label, uri string) *URITemplateAction {
@@ -233,6 +405,16 @@ func NewURITemplateAction(label, uri string) *URITemplateAction {
 	}
 }
 
+// NewURITemplateActionWithAltURI function
+// `desktopURI` is opened instead of `uri` on LINE for macOS and Windows.
+func NewURITemplateActionWithAltURI(label, uri, desktopURI string) *URITemplateAction {
+	return &URITemplateAction{
+		Label:         label,
+		URI:           uri,
+		AltURIDesktop: desktopURI,
+	}
+}
+
 // NewMessageTemplateAction function
 func NewMessageTemplateAction(label, text string) *MessageTemplateAction {
 	return &MessageTemplateAction{
@@ -249,3 +431,38 @@ func NewPostbackTemplateAction(label, data, text string) *PostbackTemplateAction
 		Text:  text,
 	}
 }
+
+// NewDatetimePickerAction function
+// `mode` is one of "date", "time" or "datetime".
+// `initial`, `max` and `min` are optional. they can be empty.
+func NewDatetimePickerAction(label, data, mode, initial, max, min string) *DatetimePickerTemplateAction {
+	return &DatetimePickerTemplateAction{
+		Label:   label,
+		Data:    data,
+		Mode:    DatetimePickerMode(mode),
+		Initial: initial,
+		Max:     max,
+		Min:     min,
+	}
+}
+
+// NewCameraAction function
+func NewCameraAction(label string) *CameraTemplateAction {
+	return &CameraTemplateAction{
+		Label: label,
+	}
+}
+
+// NewCameraRollAction function
+func NewCameraRollAction(label string) *CameraRollTemplateAction {
+	return &CameraRollTemplateAction{
+		Label: label,
+	}
+}
+
+// NewLocationAction function
+func NewLocationAction(label string) *LocationTemplateAction {
+	return &LocationTemplateAction{
+		Label: label,
+	}
+}
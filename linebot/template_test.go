@@ -0,0 +1,194 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDatetimePickerAction(t *testing.T) {
+	var testCases = []struct {
+		Action *DatetimePickerTemplateAction
+		Want   string
+	}{
+		{
+			Action: NewDatetimePickerAction("Pick date", "action=sel&only=date", "date", "2017-09-01", "2017-09-30", "2017-09-01"),
+			Want:   `{"type":"datetimepicker","label":"Pick date","data":"action=sel\u0026only=date","mode":"date","initial":"2017-09-01","max":"2017-09-30","min":"2017-09-01"}`,
+		},
+		{
+			Action: NewDatetimePickerAction("Pick time", "action=sel&only=time", "time", "12:00", "23:59", "00:00"),
+			Want:   `{"type":"datetimepicker","label":"Pick time","data":"action=sel\u0026only=time","mode":"time","initial":"12:00","max":"23:59","min":"00:00"}`,
+		},
+		{
+			Action: NewDatetimePickerAction("Pick datetime", "action=sel", "datetime", "2017-09-01T12:00", "2017-09-30T23:59", "2017-09-01T00:00"),
+			Want:   `{"type":"datetimepicker","label":"Pick datetime","data":"action=sel","mode":"datetime","initial":"2017-09-01T12:00","max":"2017-09-30T23:59","min":"2017-09-01T00:00"}`,
+		},
+		{
+			// Without optional fields
+			Action: NewDatetimePickerAction("Pick datetime", "action=sel", "datetime", "", "", ""),
+			Want:   `{"type":"datetimepicker","label":"Pick datetime","data":"action=sel","mode":"datetime"}`,
+		},
+	}
+	for i, tc := range testCases {
+		got, err := json.Marshal(tc.Action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("Action %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
+
+func TestQuickReplyOnlyActions(t *testing.T) {
+	var testCases = []struct {
+		Action TemplateAction
+		Want   string
+	}{
+		{
+			Action: NewCameraAction("Camera"),
+			Want:   `{"type":"camera","label":"Camera"}`,
+		},
+		{
+			Action: NewCameraRollAction("Camera roll"),
+			Want:   `{"type":"cameraRoll","label":"Camera roll"}`,
+		},
+		{
+			Action: NewLocationAction("Location"),
+			Want:   `{"type":"location","label":"Location"}`,
+		},
+	}
+	for i, tc := range testCases {
+		got, err := json.Marshal(tc.Action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("Action %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
+
+func TestURITemplateAction(t *testing.T) {
+	var testCases = []struct {
+		Action *URITemplateAction
+		Want   string
+	}{
+		{
+			Action: NewURITemplateAction("View detail", "https://example.com/page/222"),
+			Want:   `{"type":"uri","label":"View detail","uri":"https://example.com/page/222"}`,
+		},
+		{
+			Action: NewURITemplateActionWithAltURI("View detail", "https://example.com/page/222", "https://example.com/pc/page/222"),
+			Want:   `{"type":"uri","label":"View detail","uri":"https://example.com/page/222","altUri":{"desktop":"https://example.com/pc/page/222"}}`,
+		},
+	}
+	for i, tc := range testCases {
+		got, err := json.Marshal(tc.Action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("Action %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
+
+func TestDisplayText(t *testing.T) {
+	var testCases = []struct {
+		Action TemplateAction
+		Want   string
+	}{
+		{
+			Action: NewMessageTemplateAction("Yes", "yes"),
+			Want:   `{"type":"message","label":"Yes","text":"yes"}`,
+		},
+		{
+			Action: NewMessageTemplateAction("Yes", "yes").WithDisplayText("Yes!"),
+			Want:   `{"type":"message","label":"Yes","text":"yes","displayText":"Yes!"}`,
+		},
+		{
+			Action: NewPostbackTemplateAction("Buy", "action=buy", "buy"),
+			Want:   `{"type":"postback","label":"Buy","data":"action=buy","text":"buy"}`,
+		},
+		{
+			Action: NewPostbackTemplateAction("Buy", "action=buy", "buy").WithDisplayText("I'll buy it"),
+			Want:   `{"type":"postback","label":"Buy","data":"action=buy","displayText":"I'll buy it"}`,
+		},
+		{
+			// Text is not sent together with DisplayText
+			Action: &PostbackTemplateAction{Label: "Buy", Data: "action=buy", Text: "buy", DisplayText: "I'll buy it"},
+			Want:   `{"type":"postback","label":"Buy","data":"action=buy","displayText":"I'll buy it"}`,
+		},
+	}
+	for i, tc := range testCases {
+		got, err := json.Marshal(tc.Action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.Want {
+			t.Errorf("Action %d %s; want %s", i, got, tc.Want)
+		}
+	}
+}
+
+func TestPostbackDataLength(t *testing.T) {
+	var testCases = []struct {
+		Action TemplateAction
+		Error  error
+	}{
+		{
+			Action: NewPostbackTemplateAction("Buy", strings.Repeat("a", 300), ""),
+		},
+		{
+			Action: NewPostbackTemplateAction("Buy", strings.Repeat("a", 301), ""),
+			Error:  &ValidationError{Field: "data", Reason: "must be at most 300 bytes"},
+		},
+		{
+			// 100 characters of 3 bytes
+			Action: NewPostbackTemplateAction("Buy", strings.Repeat("あ", 100), ""),
+		},
+		{
+			Action: NewPostbackTemplateAction("Buy", strings.Repeat("あ", 100)+"a", ""),
+			Error:  &ValidationError{Field: "data", Reason: "must be at most 300 bytes"},
+		},
+		{
+			Action: NewDatetimePickerAction("Pick date", strings.Repeat("a", 301), "date", "", "", ""),
+			Error:  &ValidationError{Field: "data", Reason: "must be at most 300 bytes"},
+		},
+	}
+	for i, tc := range testCases {
+		err := tc.Action.(validator).Validate()
+		if !reflect.DeepEqual(err, tc.Error) {
+			t.Errorf("Validate %d %v; want %v", i, err, tc.Error)
+		}
+	}
+
+	message := NewTemplateMessage("Carousel", NewCarouselTemplate(
+		NewCarouselColumn("", "", "Item 1", NewPostbackTemplateAction("Buy", "action=buy", "")),
+		NewCarouselColumn("", "", "Item 2", NewPostbackTemplateAction("Buy", strings.Repeat("a", 301), "")),
+	))
+	err := validateMessages([]Message{message})
+	want := &ValidationError{
+		Field:  "messages[0].template.columns[1].actions[0].data",
+		Reason: "must be at most 300 bytes",
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("validateMessages %v; want %v", err, want)
+	}
+}
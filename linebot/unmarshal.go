@@ -0,0 +1,415 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// UnmarshalMessage function
+// It decodes a message marshaled by one of the Message types, which is useful
+// for storing messages as JSON and sending them later.
+func UnmarshalMessage(data []byte) (Message, error) {
+	raw := struct {
+		Type               MessageType       `json:"type"`
+		ID                 string            `json:"id"`
+		Text               string            `json:"text"`
+		Emojis             []*Emoji          `json:"emojis"`
+		OriginalContentURL string            `json:"originalContentUrl"`
+		PreviewImageURL    string            `json:"previewImageUrl"`
+		Duration           int               `json:"duration"`
+		FileName           string            `json:"fileName"`
+		FileSize           int               `json:"fileSize"`
+		Title              string            `json:"title"`
+		Address            string            `json:"address"`
+		Latitude           float64           `json:"latitude"`
+		Longitude          float64           `json:"longitude"`
+		PackageID          string            `json:"packageId"`
+		StickerID          string            `json:"stickerId"`
+		AltText            string            `json:"altText"`
+		Template           json.RawMessage   `json:"template"`
+		BaseURL            string            `json:"baseUrl"`
+		BaseSize           ImagemapBaseSize  `json:"baseSize"`
+		Actions            []json.RawMessage `json:"actions"`
+		Contents           json.RawMessage   `json:"contents"`
+		QuickReply         *struct {
+			Items []*struct {
+				ImageURL string          `json:"imageUrl"`
+				Action   json.RawMessage `json:"action"`
+			} `json:"items"`
+		} `json:"quickReply"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var quickReply *QuickReply
+	if raw.QuickReply != nil {
+		quickReply = &QuickReply{}
+		for _, item := range raw.QuickReply.Items {
+			action, err := unmarshalTemplateAction(item.Action)
+			if err != nil {
+				return nil, err
+			}
+			quickReply.Items = append(quickReply.Items, NewQuickReplyButton(item.ImageURL, action))
+		}
+	}
+
+	switch raw.Type {
+	case MessageTypeText:
+		return &TextMessage{
+			Text:       raw.Text,
+			Emojis:     raw.Emojis,
+			QuickReply: quickReply,
+		}, nil
+	case MessageTypeImage:
+		return &ImageMessage{
+			OriginalContentURL: raw.OriginalContentURL,
+			PreviewImageURL:    raw.PreviewImageURL,
+			QuickReply:         quickReply,
+		}, nil
+	case MessageTypeVideo:
+		return &VideoMessage{
+			OriginalContentURL: raw.OriginalContentURL,
+			PreviewImageURL:    raw.PreviewImageURL,
+			QuickReply:         quickReply,
+		}, nil
+	case MessageTypeAudio:
+		return &AudioMessage{
+			OriginalContentURL: raw.OriginalContentURL,
+			Duration:           raw.Duration,
+			QuickReply:         quickReply,
+		}, nil
+	case MessageTypeFile:
+		return &FileMessage{
+			ID:       raw.ID,
+			FileName: raw.FileName,
+			FileSize: raw.FileSize,
+		}, nil
+	case MessageTypeLocation:
+		return &LocationMessage{
+			Title:      raw.Title,
+			Address:    raw.Address,
+			Latitude:   raw.Latitude,
+			Longitude:  raw.Longitude,
+			QuickReply: quickReply,
+		}, nil
+	case MessageTypeSticker:
+		return &StickerMessage{
+			PackageID:  raw.PackageID,
+			StickerID:  raw.StickerID,
+			QuickReply: quickReply,
+		}, nil
+	case MessageTypeTemplate:
+		template, err := unmarshalTemplate(raw.Template)
+		if err != nil {
+			return nil, err
+		}
+		return &TemplateMessage{
+			AltText:    raw.AltText,
+			Template:   template,
+			QuickReply: quickReply,
+		}, nil
+	case MessageTypeImagemap:
+		var actions []ImagemapAction
+		for _, d := range raw.Actions {
+			action, err := unmarshalImagemapAction(d)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, action)
+		}
+		return &ImagemapMessage{
+			BaseURL:    raw.BaseURL,
+			AltText:    raw.AltText,
+			BaseSize:   raw.BaseSize,
+			Actions:    actions,
+			QuickReply: quickReply,
+		}, nil
+	case MessageTypeFlex:
+		contents, err := unmarshalFlexContainer(raw.Contents)
+		if err != nil {
+			return nil, err
+		}
+		return &FlexMessage{
+			AltText:    raw.AltText,
+			Contents:   contents,
+			QuickReply: quickReply,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown message type: %q", raw.Type)
+}
+
+// LoadMessagesFromJSON function
+// It decodes a JSON array of messages, e.g. canned replies kept in a file.
+func LoadMessagesFromJSON(data []byte) ([]Message, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	messages := make([]Message, len(raw))
+	for i, d := range raw {
+		message, err := UnmarshalMessage(d)
+		if err != nil {
+			return nil, fmt.Errorf("messages[%d]: %v", i, err)
+		}
+		messages[i] = message
+	}
+	return messages, nil
+}
+
+func unmarshalTemplate(data []byte) (Template, error) {
+	type rawColumn struct {
+		ThumbnailImageURL string            `json:"thumbnailImageUrl"`
+		Title             string            `json:"title"`
+		Text              string            `json:"text"`
+		Actions           []json.RawMessage `json:"actions"`
+	}
+	raw := struct {
+		Type TemplateType `json:"type"`
+		rawColumn
+		Columns []*rawColumn `json:"columns"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	switch raw.Type {
+	case TemplateTypeButtons:
+		actions, err := unmarshalTemplateActions(raw.Actions)
+		if err != nil {
+			return nil, err
+		}
+		return NewButtonsTemplate(raw.ThumbnailImageURL, raw.Title, raw.Text, actions...), nil
+	case TemplateTypeConfirm:
+		actions, err := unmarshalTemplateActions(raw.Actions)
+		if err != nil {
+			return nil, err
+		}
+		return &ConfirmTemplate{Text: raw.Text, Actions: actions}, nil
+	case TemplateTypeCarousel:
+		var columns []*CarouselColumn
+		for _, column := range raw.Columns {
+			actions, err := unmarshalTemplateActions(column.Actions)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, NewCarouselColumn(column.ThumbnailImageURL, column.Title, column.Text, actions...))
+		}
+		return NewCarouselTemplate(columns...), nil
+	}
+	return nil, fmt.Errorf("unknown template type: %q", raw.Type)
+}
+
+func unmarshalTemplateActions(data []json.RawMessage) ([]TemplateAction, error) {
+	var actions []TemplateAction
+	for _, d := range data {
+		action, err := unmarshalTemplateAction(d)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func unmarshalTemplateAction(data []byte) (TemplateAction, error) {
+	raw := struct {
+		Type   TemplateActionType `json:"type"`
+		Label  string             `json:"label"`
+		URI    string             `json:"uri"`
+		AltURI *struct {
+			Desktop string `json:"desktop"`
+		} `json:"altUri"`
+		Text        string             `json:"text"`
+		DisplayText string             `json:"displayText"`
+		Data        string             `json:"data"`
+		Mode        DatetimePickerMode `json:"mode"`
+		Initial     string             `json:"initial"`
+		Max         string             `json:"max"`
+		Min         string             `json:"min"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	switch raw.Type {
+	case TemplateActionTypeURI:
+		action := NewURITemplateAction(raw.Label, raw.URI)
+		if raw.AltURI != nil {
+			action.AltURIDesktop = raw.AltURI.Desktop
+		}
+		return action, nil
+	case TemplateActionTypeMessage:
+		return &MessageTemplateAction{
+			Label:       raw.Label,
+			Text:        raw.Text,
+			DisplayText: raw.DisplayText,
+		}, nil
+	case TemplateActionTypePostback:
+		return &PostbackTemplateAction{
+			Label:       raw.Label,
+			Data:        raw.Data,
+			Text:        raw.Text,
+			DisplayText: raw.DisplayText,
+		}, nil
+	case TemplateActionTypeDatetimePicker:
+		return &DatetimePickerTemplateAction{
+			Label:   raw.Label,
+			Data:    raw.Data,
+			Mode:    raw.Mode,
+			Initial: raw.Initial,
+			Max:     raw.Max,
+			Min:     raw.Min,
+		}, nil
+	case TemplateActionTypeCamera:
+		return NewCameraAction(raw.Label), nil
+	case TemplateActionTypeCameraRoll:
+		return NewCameraRollAction(raw.Label), nil
+	case TemplateActionTypeLocation:
+		return NewLocationAction(raw.Label), nil
+	}
+	return nil, fmt.Errorf("unknown template action type: %q", raw.Type)
+}
+
+func unmarshalImagemapAction(data []byte) (ImagemapAction, error) {
+	raw := struct {
+		Type    ImagemapActionType `json:"type"`
+		LinkURL string             `json:"linkUri"`
+		Text    string             `json:"text"`
+		Area    ImagemapArea       `json:"area"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	switch raw.Type {
+	case ImagemapActionTypeURI:
+		return NewURIImagemapAction(raw.LinkURL, raw.Area), nil
+	case ImagemapActionTypeMessage:
+		return NewMessageImagemapAction(raw.Text, raw.Area), nil
+	}
+	return nil, fmt.Errorf("unknown imagemap action type: %q", raw.Type)
+}
+
+func unmarshalFlexContainer(data []byte) (FlexContainer, error) {
+	raw := struct {
+		Type     FlexContainerType `json:"type"`
+		Contents []json.RawMessage `json:"contents"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	switch raw.Type {
+	case FlexContainerTypeBubble:
+		return unmarshalBubbleContainer(data)
+	case FlexContainerTypeCarousel:
+		var contents []*BubbleContainer
+		for _, d := range raw.Contents {
+			bubble, err := unmarshalBubbleContainer(d)
+			if err != nil {
+				return nil, err
+			}
+			contents = append(contents, bubble)
+		}
+		return &CarouselContainer{Contents: contents}, nil
+	}
+	return nil, fmt.Errorf("unknown flex container type: %q", raw.Type)
+}
+
+func unmarshalBubbleContainer(data []byte) (*BubbleContainer, error) {
+	raw := struct {
+		Header json.RawMessage `json:"header"`
+		Body   json.RawMessage `json:"body"`
+		Footer json.RawMessage `json:"footer"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	bubble := &BubbleContainer{}
+	for _, block := range []struct {
+		data json.RawMessage
+		box  **BoxComponent
+	}{
+		{raw.Header, &bubble.Header},
+		{raw.Body, &bubble.Body},
+		{raw.Footer, &bubble.Footer},
+	} {
+		if block.data == nil {
+			continue
+		}
+		component, err := unmarshalFlexComponent(block.data)
+		if err != nil {
+			return nil, err
+		}
+		box, ok := component.(*BoxComponent)
+		if !ok {
+			return nil, errors.New("bubble blocks must be box components")
+		}
+		*block.box = box
+	}
+	return bubble, nil
+}
+
+func unmarshalFlexComponent(data []byte) (FlexComponent, error) {
+	raw := struct {
+		Type     FlexComponentType        `json:"type"`
+		Layout   FlexBoxLayoutType        `json:"layout"`
+		Contents []json.RawMessage        `json:"contents"`
+		Spacing  FlexComponentSpacingType `json:"spacing"`
+		Margin   FlexComponentMarginType  `json:"margin"`
+		Text     string                   `json:"text"`
+		Size     FlexTextSizeType         `json:"size"`
+		Weight   FlexTextWeightType       `json:"weight"`
+		Color    string                   `json:"color"`
+		Align    FlexComponentAlignType   `json:"align"`
+		Wrap     bool                     `json:"wrap"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	switch raw.Type {
+	case FlexComponentTypeBox:
+		var contents []FlexComponent
+		for _, d := range raw.Contents {
+			component, err := unmarshalFlexComponent(d)
+			if err != nil {
+				return nil, err
+			}
+			contents = append(contents, component)
+		}
+		return &BoxComponent{
+			Layout:   raw.Layout,
+			Contents: contents,
+			Spacing:  raw.Spacing,
+			Margin:   raw.Margin,
+		}, nil
+	case FlexComponentTypeText:
+		return &TextComponent{
+			Text:   raw.Text,
+			Size:   raw.Size,
+			Weight: raw.Weight,
+			Color:  raw.Color,
+			Align:  raw.Align,
+			Margin: raw.Margin,
+			Wrap:   raw.Wrap,
+		}, nil
+	case FlexComponentTypeSeparator:
+		return &SeparatorComponent{
+			Margin: raw.Margin,
+			Color:  raw.Color,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown flex component type: %q", raw.Type)
+}
@@ -0,0 +1,142 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalMessage(t *testing.T) {
+	quickReply := NewQuickReply(
+		NewQuickReplyButton("https://example.com/camera.png", NewCameraAction("Camera")),
+		NewQuickReplyButton("", NewCameraRollAction("Camera roll")),
+		NewQuickReplyButton("", NewLocationAction("Location")),
+	)
+	var testCases = []Message{
+		NewTextMessage("Hello, $").AddEmoji(NewEmoji(7, "5ac1bfd5040ab15980c9b435", "001")).WithQuickReply(quickReply),
+		NewImageMessage("https://example.com/original.jpg", "https://example.com/preview.jpg"),
+		NewVideoMessage("https://example.com/original.mp4", "https://example.com/preview.jpg"),
+		NewAudioMessage("https://example.com/original.m4a", 1000),
+		&FileMessage{ID: "325708", FileName: "file.txt", FileSize: 2138},
+		NewLocationMessage("title", "address", 35.65910807942215, 139.70372892916203),
+		NewStickerMessage("1", "1"),
+		NewTemplateMessage(
+			"this is a buttons template",
+			NewButtonsTemplate(
+				"https://example.com/bot/images/image.jpg",
+				"Menu",
+				"Please select",
+				NewPostbackTemplateAction("Buy", "action=buy&itemid=123", "").WithDisplayText("Buy"),
+				NewURITemplateActionWithAltURI("View detail", "https://example.com/page/123", "https://example.com/pc/page/123"),
+				NewDatetimePickerAction("Select date", "storeId=12345", "datetime", "2017-12-25t00:00", "2018-01-24t23:59", "2017-12-25t00:00"),
+			),
+		),
+		NewTemplateMessage(
+			"this is a confirm template",
+			NewConfirmTemplate(
+				"Are you sure?",
+				NewMessageTemplateAction("Yes", "yes").WithDisplayText("Yes!"),
+				NewMessageTemplateAction("No", "no"),
+			),
+		),
+		NewTemplateMessage(
+			"this is a carousel template",
+			NewCarouselTemplate(
+				NewCarouselColumn("", "", "hoge", NewPostbackTemplateAction("Buy", "action=buy&itemid=111", "buy")),
+				NewCarouselColumn("https://example.com/bot/images/item2.jpg", "this is menu", "fuga", NewURITemplateAction("View", "https://example.com/page/222")),
+			),
+		),
+		NewImagemapMessage(
+			"https://example.com/bot/images/rm001",
+			"this is an imagemap",
+			ImagemapBaseSize{Width: 1040, Height: 1040},
+			NewURIImagemapAction("https://example.com/", ImagemapArea{X: 520, Y: 0, Width: 520, Height: 1040}),
+			NewMessageImagemapAction("hello", ImagemapArea{X: 520, Y: 0, Width: 520, Height: 1040}),
+		),
+		NewFlexMessage(
+			"this is a flex message",
+			&CarouselContainer{
+				Contents: []*BubbleContainer{
+					{
+						Body: &BoxComponent{
+							Layout: FlexBoxLayoutTypeVertical,
+							Contents: []FlexComponent{
+								&TextComponent{Text: "hello", Size: FlexTextSizeTypeXl, Weight: FlexTextWeightTypeBold, Wrap: true},
+								&SeparatorComponent{Margin: FlexComponentMarginTypeMd},
+								&BoxComponent{
+									Layout:   FlexBoxLayoutTypeHorizontal,
+									Contents: []FlexComponent{&TextComponent{Text: "world", Align: FlexComponentAlignTypeEnd}},
+									Spacing:  FlexComponentSpacingTypeSm,
+								},
+							},
+						},
+					},
+					NewReceiptBubble("RECEIPT", []ReceiptItem{{Name: "Coffee", Price: "$3.00"}}, "$3.00"),
+				},
+			},
+		),
+	}
+	for i, want := range testCases {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := UnmarshalMessage(data)
+		if err != nil {
+			t.Errorf("UnmarshalMessage %d %s: %v", i, data, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("UnmarshalMessage %d %s\n got %#v\nwant %#v", i, data, got, want)
+		}
+	}
+}
+
+func TestUnmarshalMessageUnknownType(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"unknown"}`,
+		`{"type":"template","altText":"alt","template":{"type":"unknown"}}`,
+		`{"type":"flex","altText":"alt","contents":{"type":"bubble","body":{"type":"unknown"}}}`,
+	} {
+		if _, err := UnmarshalMessage([]byte(data)); err == nil {
+			t.Errorf("UnmarshalMessage %s: expected an error", data)
+		}
+	}
+}
+
+func TestLoadMessagesFromJSON(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/messages.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadMessagesFromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Message{
+		NewTextMessage("Thanks for your message!"),
+		NewImageMessage("https://example.com/original.jpg", "https://example.com/preview.jpg"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadMessagesFromJSON %v; want %v", got, want)
+	}
+
+	if _, err := LoadMessagesFromJSON([]byte(`[{"type":"text","text":"ok"},{"type":"unknown"}]`)); err == nil {
+		t.Error("expected an error for an unknown message type")
+	}
+}
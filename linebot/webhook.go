@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+
+	"golang.org/x/net/context"
 )
 
 // ParseRequest method
@@ -28,16 +30,52 @@ func (client *Client) ParseRequest(r *http.Request) ([]*Event, error) {
 	return ParseRequest(client.channelSecret, r)
 }
 
+// WebhookHandler method
+// It returns a http.Handler which parses the webhook request and passes the
+// events to `fn`. Requests with an invalid signature or body are answered with
+// 400 Bad Request without calling `fn`; otherwise 200 OK unless `fn` writes
+// another status.
+func (client *Client) WebhookHandler(fn func([]*Event, http.ResponseWriter, *http.Request)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events, err := client.ParseRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fn(events, w, r)
+	})
+}
+
+// ParseRequestWithContext method
+func (client *Client) ParseRequestWithContext(ctx context.Context, r *http.Request) ([]*Event, error) {
+	return ParseRequestWithContext(ctx, client.channelSecret, r)
+}
+
 // ParseRequest func
 func ParseRequest(channelSecret string, r *http.Request) ([]*Event, error) {
+	return ParseRequestWithContext(context.Background(), channelSecret, r)
+}
+
+// ParseRequestWithContext func
+// It returns the error of `ctx` as soon as it is done between the parsing steps.
+func ParseRequestWithContext(ctx context.Context, channelSecret string, r *http.Request) ([]*Event, error) {
 	defer r.Body.Close()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		return nil, err
 	}
-	if !validateSignature(channelSecret, r.Header.Get("X-Line-Signature"), body) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidateSignature(channelSecret, r.Header.Get("X-Line-Signature"), body) {
 		return nil, ErrInvalidSignature
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	request := &struct {
 		Events []*Event `json:"events"`
@@ -45,10 +83,17 @@ func ParseRequest(channelSecret string, r *http.Request) ([]*Event, error) {
 	if err = json.Unmarshal(body, request); err != nil {
 		return nil, err
 	}
+	if request.Events == nil {
+		// Webhook verification requests may have no events
+		return []*Event{}, nil
+	}
 	return request.Events, nil
 }
 
-func validateSignature(channelSecret, signature string, body []byte) bool {
+// ValidateSignature function
+// It reports whether `signature`, the value of the X-Line-Signature header,
+// is the base64 encoded HMAC-SHA256 digest of `body` with `channelSecret`.
+func ValidateSignature(channelSecret, signature string, body []byte) bool {
 	decoded, err := base64.StdEncoding.DecodeString(signature)
 	if err != nil {
 		return false
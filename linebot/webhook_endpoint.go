@@ -0,0 +1,146 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"bytes"
+	"io"
+
+	"golang.org/x/net/context"
+)
+
+// SetWebhookEndpoint method
+func (client *Client) SetWebhookEndpoint(endpoint string) *SetWebhookEndpointCall {
+	return &SetWebhookEndpointCall{
+		c:        client,
+		endpoint: endpoint,
+	}
+}
+
+// SetWebhookEndpointCall type
+type SetWebhookEndpointCall struct {
+	c   *Client
+	ctx context.Context
+
+	endpoint string
+}
+
+// WithContext method
+func (call *SetWebhookEndpointCall) WithContext(ctx context.Context) *SetWebhookEndpointCall {
+	call.ctx = ctx
+	return call
+}
+
+func (call *SetWebhookEndpointCall) encodeJSON(w io.Writer) error {
+	return call.c.encodeJSON(w, &struct {
+		Endpoint string `json:"endpoint"`
+	}{
+		Endpoint: call.endpoint,
+	})
+}
+
+// Do method
+func (call *SetWebhookEndpointCall) Do() (*BasicResponse, error) {
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	res, err := call.c.put(call.ctx, APIEndpointWebhookEndpoint, buf.Bytes())
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToBasicResponse(res, call.c.codec)
+}
+
+// GetWebhookEndpoint method
+func (client *Client) GetWebhookEndpoint() *GetWebhookEndpointCall {
+	return &GetWebhookEndpointCall{
+		c: client,
+	}
+}
+
+// GetWebhookEndpointCall type
+type GetWebhookEndpointCall struct {
+	c   *Client
+	ctx context.Context
+}
+
+// WithContext method
+func (call *GetWebhookEndpointCall) WithContext(ctx context.Context) *GetWebhookEndpointCall {
+	call.ctx = ctx
+	return call
+}
+
+// Do method
+func (call *GetWebhookEndpointCall) Do() (*WebhookInfoResponse, error) {
+	res, err := call.c.get(call.ctx, APIEndpointWebhookEndpoint, nil)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToWebhookInfoResponse(res, call.c.codec)
+}
+
+// TestWebhookEndpoint method
+// `endpoint` is optional. The configured webhook URL is tested when it's empty.
+func (client *Client) TestWebhookEndpoint(endpoint string) *TestWebhookEndpointCall {
+	return &TestWebhookEndpointCall{
+		c:        client,
+		endpoint: endpoint,
+	}
+}
+
+// TestWebhookEndpointCall type
+type TestWebhookEndpointCall struct {
+	c   *Client
+	ctx context.Context
+
+	endpoint string
+}
+
+// WithContext method
+func (call *TestWebhookEndpointCall) WithContext(ctx context.Context) *TestWebhookEndpointCall {
+	call.ctx = ctx
+	return call
+}
+
+func (call *TestWebhookEndpointCall) encodeJSON(w io.Writer) error {
+	return call.c.encodeJSON(w, &struct {
+		Endpoint string `json:"endpoint,omitempty"`
+	}{
+		Endpoint: call.endpoint,
+	})
+}
+
+// Do method
+func (call *TestWebhookEndpointCall) Do() (*TestWebhookResponse, error) {
+	var buf bytes.Buffer
+	if err := call.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	res, err := call.c.post(call.ctx, APIEndpointTestWebhookEndpoint, buf.Bytes())
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeToTestWebhookResponse(res, call.c.codec)
+}
@@ -0,0 +1,138 @@
+// Copyright 2016 LINE Corporation
+//
+// LINE Corporation licenses this file to you under the Apache License,
+// version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at:
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package linebot
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhookEndpoint(t *testing.T) {
+	type want struct {
+		Method      string
+		URLPath     string
+		RequestBody []byte
+		Response    interface{}
+	}
+	var testCases = []struct {
+		Do       func(*Client) (interface{}, error)
+		Response []byte
+		Want     want
+	}{
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.SetWebhookEndpoint("https://example.com/callback").Do()
+			},
+			Response: []byte(`{}`),
+			Want: want{
+				Method:      http.MethodPut,
+				URLPath:     APIEndpointWebhookEndpoint,
+				RequestBody: []byte(`{"endpoint":"https://example.com/callback"}` + "\n"),
+				Response:    &BasicResponse{},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.GetWebhookEndpoint().Do()
+			},
+			Response: []byte(`{"endpoint":"https://example.com/callback","active":true}`),
+			Want: want{
+				Method:      http.MethodGet,
+				URLPath:     APIEndpointWebhookEndpoint,
+				RequestBody: []byte(""),
+				Response: &WebhookInfoResponse{
+					Endpoint: "https://example.com/callback",
+					Active:   true,
+				},
+			},
+		},
+		{
+			Do: func(client *Client) (interface{}, error) {
+				return client.TestWebhookEndpoint("https://example.com/callback").Do()
+			},
+			Response: []byte(`{"success":false,"timestamp":"2020-09-30T05:38:20.031Z","statusCode":500,"reason":"ERROR_STATUS_CODE","detail":"500"}`),
+			Want: want{
+				Method:      http.MethodPost,
+				URLPath:     APIEndpointTestWebhookEndpoint,
+				RequestBody: []byte(`{"endpoint":"https://example.com/callback"}` + "\n"),
+				Response: &TestWebhookResponse{
+					Success:    false,
+					Timestamp:  time.Date(2020, time.September, 30, 5, 38, 20, int(31*time.Millisecond), time.UTC),
+					StatusCode: 500,
+					Reason:     "ERROR_STATUS_CODE",
+					Detail:     "500",
+				},
+			},
+		},
+		{
+			// Test the configured endpoint
+			Do: func(client *Client) (interface{}, error) {
+				return client.TestWebhookEndpoint("").Do()
+			},
+			Response: []byte(`{"success":true,"timestamp":"2020-09-30T05:38:20.031Z","statusCode":200,"reason":"OK","detail":"200"}`),
+			Want: want{
+				Method:      http.MethodPost,
+				URLPath:     APIEndpointTestWebhookEndpoint,
+				RequestBody: []byte(`{}` + "\n"),
+				Response: &TestWebhookResponse{
+					Success:    true,
+					Timestamp:  time.Date(2020, time.September, 30, 5, 38, 20, int(31*time.Millisecond), time.UTC),
+					StatusCode: 200,
+					Reason:     "OK",
+					Detail:     "200",
+				},
+			},
+		},
+	}
+
+	var currentTestIdx int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		tc := testCases[currentTestIdx]
+		if r.Method != tc.Want.Method {
+			t.Errorf("Method %s; want %s", r.Method, tc.Want.Method)
+		}
+		if r.URL.Path != tc.Want.URLPath {
+			t.Errorf("URLPath %s; want %s", r.URL.Path, tc.Want.URLPath)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(body, tc.Want.RequestBody) {
+			t.Errorf("RequestBody %s; want %s", body, tc.Want.RequestBody)
+		}
+		w.Write(tc.Response)
+	}))
+	defer server.Close()
+	client, err := mockClient(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tc := range testCases {
+		currentTestIdx = i
+		res, err := tc.Do(client)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(res, tc.Want.Response) {
+			t.Errorf("Response %d %v; want %v", i, res, tc.Want.Response)
+		}
+	}
+}
@@ -24,8 +24,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"golang.org/x/net/context"
 )
 
 var webhookTestRequestBody = `{
@@ -42,6 +45,9 @@ var webhookTestRequestBody = `{
                 "id": "325708",
                 "type": "text",
                 "text": "Hello, world"
+            },
+            "deliveryContext": {
+                "isRedelivery": false
             }
         },
         {
@@ -59,6 +65,21 @@ var webhookTestRequestBody = `{
                 "text": "Hello, world"
             }
         },
+        {
+            "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+            "type": "message",
+            "timestamp": 1462629479859,
+            "source": {
+                "type": "room",
+                "roomId": "u206d25c2ea6bd87c17655609a1c37cb8",
+                "userId": "u206d25c2ea6bd87c17655609a1c37cb8"
+            },
+            "message": {
+                "id": "325708",
+                "type": "text",
+                "text": "Hello, world"
+            }
+        },
         {
             "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
             "type": "message",
@@ -162,6 +183,81 @@ var webhookTestRequestBody = `{
                 "hwid":"374591320",
                 "type":"enter"
             }
+        },
+        {
+            "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+            "type": "beacon",
+            "timestamp": 1462629479859,
+            "source": {
+                "type": "user",
+                "userId": "U012345678901234567890123456789ab"
+            },
+            "beacon": {
+                "hwid":"374591320",
+                "type":"enter",
+                "dm":"1234567890abcdef"
+            }
+        },
+        {
+            "type": "delivery",
+            "timestamp": 1462629479859,
+            "source": {
+                "type": "user",
+                "userId": "U012345678901234567890123456789ab"
+            },
+            "delivery": {
+                "data": "53b00b8ad3e5ac2c6d44a5c9e3b9ad0e"
+            }
+        },
+        {
+            "replyToken": "b60d432864f44d079f6d8efe86cf404b",
+            "type": "accountLink",
+            "timestamp": 1462629479859,
+            "source": {
+                "type": "user",
+                "userId": "U91eeaf62d..."
+            },
+            "link": {
+                "result": "ok",
+                "nonce": "xxxxxxxxxxxxxxx"
+            }
+        },
+        {
+            "replyToken": "0f3779fba3b349968c5d07db31eabf65",
+            "type": "memberJoined",
+            "timestamp": 1462629479859,
+            "source": {
+                "type": "group",
+                "groupId": "C4af4980629..."
+            },
+            "joined": {
+                "members": [
+                    {
+                        "type": "user",
+                        "userId": "U4af4980629..."
+                    },
+                    {
+                        "type": "user",
+                        "userId": "U91eeaf62d9..."
+                    }
+                ]
+            }
+        },
+        {
+            "type": "memberLeft",
+            "timestamp": 1462629479859,
+            "source": {
+                "type": "group",
+                "groupId": "C4af4980629..."
+            },
+            "left": {
+                "members": [
+                    {
+                        "type": "user",
+                        "userId": "U4af4980629..."
+                    }
+                ]
+            }
         }
     ]
 }
@@ -180,6 +276,7 @@ var webhookTestWantEvents = []*Event{
 			ID:   "325708",
 			Text: "Hello, world",
 		},
+		DeliveryContext: &DeliveryContext{IsRedelivery: false},
 	},
 	{
 		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
@@ -195,6 +292,20 @@ var webhookTestWantEvents = []*Event{
 			Text: "Hello, world",
 		},
 	},
+	{
+		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+		Type:       EventTypeMessage,
+		Timestamp:  time.Date(2016, time.May, 7, 13, 57, 59, int(859*time.Millisecond), time.UTC),
+		Source: &EventSource{
+			Type:   EventSourceTypeRoom,
+			UserID: "u206d25c2ea6bd87c17655609a1c37cb8",
+			RoomID: "u206d25c2ea6bd87c17655609a1c37cb8",
+		},
+		Message: &TextMessage{
+			ID:   "325708",
+			Text: "Hello, world",
+		},
+	},
 	{
 		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
 		Type:       EventTypeMessage,
@@ -296,6 +407,77 @@ var webhookTestWantEvents = []*Event{
 			Type: BeaconEventTypeEnter,
 		},
 	},
+	{
+		ReplyToken: "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+		Type:       EventTypeBeacon,
+		Timestamp:  time.Date(2016, time.May, 7, 13, 57, 59, int(859*time.Millisecond), time.UTC),
+		Source: &EventSource{
+			Type:   EventSourceTypeUser,
+			UserID: "U012345678901234567890123456789ab",
+		},
+		Beacon: &Beacon{
+			Hwid:          "374591320",
+			Type:          BeaconEventTypeEnter,
+			DeviceMessage: []byte{0x12, 0x34, 0x56, 0x78, 0x90, 0xab, 0xcd, 0xef},
+		},
+	},
+	{
+		Type:      EventTypeDelivery,
+		Timestamp: time.Date(2016, time.May, 7, 13, 57, 59, int(859*time.Millisecond), time.UTC),
+		Source: &EventSource{
+			Type:   EventSourceTypeUser,
+			UserID: "U012345678901234567890123456789ab",
+		},
+		Delivery: &Delivery{
+			Data: "53b00b8ad3e5ac2c6d44a5c9e3b9ad0e",
+		},
+	},
+	{
+		ReplyToken: "b60d432864f44d079f6d8efe86cf404b",
+		Type:       EventTypeAccountLink,
+		Timestamp:  time.Date(2016, time.May, 7, 13, 57, 59, int(859*time.Millisecond), time.UTC),
+		Source: &EventSource{
+			Type:   EventSourceTypeUser,
+			UserID: "U91eeaf62d...",
+		},
+		Link: &Link{
+			Result: AccountLinkResultOK,
+			Nonce:  "xxxxxxxxxxxxxxx",
+		},
+	},
+	{
+		ReplyToken: "0f3779fba3b349968c5d07db31eabf65",
+		Type:       EventTypeMemberJoined,
+		Timestamp:  time.Date(2016, time.May, 7, 13, 57, 59, int(859*time.Millisecond), time.UTC),
+		Source: &EventSource{
+			Type:    EventSourceTypeGroup,
+			GroupID: "C4af4980629...",
+		},
+		Members: []*EventSource{
+			{
+				Type:   EventSourceTypeUser,
+				UserID: "U4af4980629...",
+			},
+			{
+				Type:   EventSourceTypeUser,
+				UserID: "U91eeaf62d9...",
+			},
+		},
+	},
+	{
+		Type:      EventTypeMemberLeft,
+		Timestamp: time.Date(2016, time.May, 7, 13, 57, 59, int(859*time.Millisecond), time.UTC),
+		Source: &EventSource{
+			Type:    EventSourceTypeGroup,
+			GroupID: "C4af4980629...",
+		},
+		Members: []*EventSource{
+			{
+				Type:   EventSourceTypeUser,
+				UserID: "U4af4980629...",
+			},
+		},
+	},
 }
 
 func TestParseRequest(t *testing.T) {
@@ -373,6 +555,428 @@ func TestParseRequest(t *testing.T) {
 	}
 }
 
+func TestValidateSignature(t *testing.T) {
+	body := []byte(webhookTestRequestBody)
+	mac := hmac.New(sha256.New, []byte("testsecret"))
+	mac.Write(body)
+	validSignature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	var testCases = []struct {
+		Signature string
+		Want      bool
+	}{
+		{Signature: validSignature, Want: true},
+		{Signature: base64.StdEncoding.EncodeToString([]byte("invalidsignature")), Want: false},
+		{Signature: "", Want: false},
+		// not base64
+		{Signature: "invalid signature!", Want: false},
+	}
+	for i, tc := range testCases {
+		if got := ValidateSignature("testsecret", tc.Signature, body); got != tc.Want {
+			t.Errorf("ValidateSignature %d %v; want %v", i, got, tc.Want)
+		}
+	}
+	if ValidateSignature("othersecret", validSignature, body) {
+		t.Errorf("ValidateSignature with another secret true; want false")
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	client, err := New("testsecret", "testtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotEvents []*Event
+	server := httptest.NewTLSServer(client.WebhookHandler(func(events []*Event, w http.ResponseWriter, r *http.Request) {
+		gotEvents = events
+	}))
+	defer server.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	body := []byte(webhookTestRequestBody)
+	mac := hmac.New(sha256.New, []byte("testsecret"))
+	mac.Write(body)
+	var testCases = []struct {
+		Signature    string
+		ResponseCode int
+		EventsLength int
+	}{
+		{
+			Signature:    base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+			ResponseCode: http.StatusOK,
+			EventsLength: len(webhookTestWantEvents),
+		},
+		{
+			Signature:    "invalidsignatue",
+			ResponseCode: http.StatusBadRequest,
+			EventsLength: 0,
+		},
+	}
+	for i, tc := range testCases {
+		gotEvents = nil
+		req, err := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Line-Signature", tc.Signature)
+		res, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != tc.ResponseCode {
+			t.Errorf("StatusCode %d %d; want %d", i, res.StatusCode, tc.ResponseCode)
+		}
+		if len(gotEvents) != tc.EventsLength {
+			t.Errorf("Events %d %d; want %d", i, len(gotEvents), tc.EventsLength)
+		}
+	}
+}
+
+func TestParseRequestWithContext(t *testing.T) {
+	body := []byte(webhookTestRequestBody)
+	mac := hmac.New(sha256.New, []byte("testsecret"))
+	mac.Write(body)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequest("POST", "", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Line-Signature", signature)
+	events, err := ParseRequestWithContext(context.Background(), "testsecret", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(webhookTestWantEvents) {
+		t.Errorf("Event length %d; want %d", len(events), len(webhookTestWantEvents))
+	}
+
+	req, err = http.NewRequest("POST", "", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Line-Signature", signature)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	events, err = ParseRequestWithContext(ctx, "testsecret", req)
+	if err != context.Canceled {
+		t.Errorf("err %v; want %v", err, context.Canceled)
+	}
+	if events != nil {
+		t.Errorf("events %v; want nil", events)
+	}
+}
+
+func TestParseRequestWithChannelSecret(t *testing.T) {
+	body := []byte(webhookTestRequestBody)
+	mac := hmac.New(sha256.New, []byte("testsecret"))
+	mac.Write(body)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	client, err := New("", "testtoken", WithChannelSecret("testsecret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest("POST", "", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Line-Signature", signature)
+	events, err := client.ParseRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(webhookTestWantEvents) {
+		t.Errorf("Event length %d; want %d", len(events), len(webhookTestWantEvents))
+	}
+
+	if _, err := New("", "testtoken"); err == nil {
+		t.Error("expected an error for a missing channel secret")
+	}
+}
+
+func TestParseRequestEmptyEvents(t *testing.T) {
+	for _, body := range []string{`{"events":[]}`, `{}`} {
+		req, err := http.NewRequest("POST", "", bytes.NewReader([]byte(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mac := hmac.New(sha256.New, []byte("testsecret"))
+		mac.Write([]byte(body))
+		req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		events, err := ParseRequest("testsecret", req)
+		if err != nil {
+			t.Errorf("err %v; want nil", err)
+		}
+		if events == nil || len(events) != 0 {
+			t.Errorf("events %v; want empty slice", events)
+		}
+	}
+}
+
+func TestParseRequestLifecycleEvents(t *testing.T) {
+	body := []byte(`{
+    "events": [
+        {
+            "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+            "type": "follow",
+            "timestamp": 1462629479859,
+            "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"}
+        },
+        {
+            "type": "unfollow",
+            "timestamp": 1462629479859,
+            "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"}
+        },
+        {
+            "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+            "type": "join",
+            "timestamp": 1462629479859,
+            "source": {"type": "group", "groupId": "cxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"}
+        },
+        {
+            "type": "leave",
+            "timestamp": 1462629479859,
+            "source": {"type": "group", "groupId": "cxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"}
+        }
+    ]
+}`)
+	req, err := http.NewRequest("POST", "", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, []byte("testsecret"))
+	mac.Write(body)
+	req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	events, err := ParseRequest("testsecret", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		Type       EventType
+		ReplyToken string
+	}{
+		{EventTypeFollow, "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA"},
+		{EventTypeUnfollow, ""},
+		{EventTypeJoin, "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA"},
+		{EventTypeLeave, ""},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Event length %d; want %d", len(events), len(want))
+	}
+	timestamp := time.Date(2016, time.May, 7, 13, 57, 59, int(859*time.Millisecond), time.UTC)
+	for i, event := range events {
+		if event.Type != want[i].Type {
+			t.Errorf("Event %d Type %v; want %v", i, event.Type, want[i].Type)
+		}
+		if event.ReplyToken != want[i].ReplyToken {
+			t.Errorf("Event %d ReplyToken %q; want %q", i, event.ReplyToken, want[i].ReplyToken)
+		}
+		if !event.Timestamp.Equal(timestamp) {
+			t.Errorf("Event %d Timestamp %v; want %v", i, event.Timestamp, timestamp)
+		}
+		if event.Source == nil {
+			t.Errorf("Event %d Source is nil", i)
+		}
+		if event.Message != nil {
+			t.Errorf("Event %d Message %v; want nil", i, event.Message)
+		}
+	}
+}
+
+func TestIncomingMessages(t *testing.T) {
+	var testCases = []struct {
+		Message string
+		Want    Message
+	}{
+		{
+			Message: `{"id":"325708","type":"text","text":"Hello, world"}`,
+			Want:    &TextMessage{ID: "325708", Text: "Hello, world"},
+		},
+		{
+			Message: `{"id":"325708","type":"image"}`,
+			Want:    &ImageMessage{ID: "325708"},
+		},
+		{
+			Message: `{"id":"325708","type":"video","duration":60000}`,
+			Want:    &VideoMessage{ID: "325708", Duration: 60000},
+		},
+		{
+			Message: `{"id":"325708","type":"audio","duration":60000}`,
+			Want:    &AudioMessage{ID: "325708", Duration: 60000},
+		},
+		{
+			Message: `{"id":"325708","type":"location","title":"my location","address":"Tokyo","latitude":35.65910807942215,"longitude":139.70372892916203}`,
+			Want:    &LocationMessage{ID: "325708", Title: "my location", Address: "Tokyo", Latitude: 35.65910807942215, Longitude: 139.70372892916203},
+		},
+		{
+			Message: `{"id":"325708","type":"sticker","packageId":"1","stickerId":"1"}`,
+			Want:    &StickerMessage{ID: "325708", PackageID: "1", StickerID: "1"},
+		},
+	}
+	for i, tc := range testCases {
+		body := `{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","type":"message","timestamp":1462629479859,"source":{"type":"user","userId":"u206d25c2ea6bd87c17655609a1c37cb8"},"message":` + tc.Message + `}`
+		event := &Event{}
+		if err := json.Unmarshal([]byte(body), event); err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(event.Message, tc.Want) {
+			t.Errorf("%d: Message %#v; want %#v", i, event.Message, tc.Want)
+		}
+	}
+}
+
+func TestIncomingLocationMessage(t *testing.T) {
+	body := []byte(`{
+    "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+    "type": "message",
+    "timestamp": 1462629479859,
+    "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"},
+    "message": {
+        "id": "325708",
+        "type": "location",
+        "title": "LINE Corporation",
+        "address": "Shibuya-ku, Tokyo",
+        "latitude": 35.65910807942215,
+        "longitude": 139.70372892916203
+    }
+}`)
+	event := &Event{}
+	if err := json.Unmarshal(body, event); err != nil {
+		t.Fatal(err)
+	}
+	message, ok := event.Message.(*LocationMessage)
+	if !ok {
+		t.Fatalf("Message %T; want *LocationMessage", event.Message)
+	}
+	if message.Title != "LINE Corporation" {
+		t.Errorf("Title %q; want %q", message.Title, "LINE Corporation")
+	}
+	if message.Address != "Shibuya-ku, Tokyo" {
+		t.Errorf("Address %q; want %q", message.Address, "Shibuya-ku, Tokyo")
+	}
+	if message.Latitude != 35.65910807942215 {
+		t.Errorf("Latitude %v; want %v", message.Latitude, 35.65910807942215)
+	}
+	if message.Longitude != 139.70372892916203 {
+		t.Errorf("Longitude %v; want %v", message.Longitude, 139.70372892916203)
+	}
+}
+
+func TestIncomingFileMessage(t *testing.T) {
+	body := []byte(`{
+    "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+    "type": "message",
+    "timestamp": 1462629479859,
+    "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"},
+    "message": {
+        "id": "325708",
+        "type": "file",
+        "fileName": "file.txt",
+        "fileSize": 2138
+    }
+}`)
+	event := &Event{}
+	if err := json.Unmarshal(body, event); err != nil {
+		t.Fatal(err)
+	}
+	message, ok := event.Message.(*FileMessage)
+	if !ok {
+		t.Fatalf("Message %T; want *FileMessage", event.Message)
+	}
+	if message.FileName != "file.txt" {
+		t.Errorf("FileName %q; want %q", message.FileName, "file.txt")
+	}
+	if message.FileSize != 2138 {
+		t.Errorf("FileSize %d; want %d", message.FileSize, 2138)
+	}
+}
+
+func TestBeaconEventWithoutBeacon(t *testing.T) {
+	body := `{"replyToken":"nHuyWiB7yP5Zw52FIkcQobQuGDXCTA","type":"beacon","timestamp":1462629479859,"source":{"type":"user","userId":"u206d25c2ea6bd87c17655609a1c37cb8"}}`
+	event := &Event{}
+	if err := json.Unmarshal([]byte(body), event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != EventTypeBeacon {
+		t.Errorf("Type %s; want %s", event.Type, EventTypeBeacon)
+	}
+	if event.Beacon != nil {
+		t.Errorf("Beacon %+v; want nil", event.Beacon)
+	}
+}
+
+func TestIncomingStickerMessage(t *testing.T) {
+	body := []byte(`{
+    "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+    "type": "message",
+    "timestamp": 1462629479859,
+    "source": {"type": "user", "userId": "u206d25c2ea6bd87c17655609a1c37cb8"},
+    "message": {
+        "id": "325708",
+        "type": "sticker",
+        "packageId": "1",
+        "stickerId": "1",
+        "stickerResourceType": "ANIMATION",
+        "keywords": ["Happy", "Smile"]
+    }
+}`)
+	event := &Event{}
+	if err := json.Unmarshal(body, event); err != nil {
+		t.Fatal(err)
+	}
+	want := &StickerMessage{
+		ID:                  "325708",
+		PackageID:           "1",
+		StickerID:           "1",
+		StickerResourceType: StickerResourceTypeAnimation,
+		Keywords:            []string{"Happy", "Smile"},
+	}
+	if !reflect.DeepEqual(event.Message, want) {
+		t.Errorf("Message %#v; want %#v", event.Message, want)
+	}
+}
+
+func TestEventTypeSwitch(t *testing.T) {
+	request := &struct {
+		Events []*Event `json:"events"`
+	}{}
+	if err := json.Unmarshal([]byte(webhookTestRequestBody), request); err != nil {
+		t.Fatal(err)
+	}
+	got := map[EventType]int{}
+	for _, event := range request.Events {
+		switch event.Type {
+		case EventTypeMessage, EventTypeFollow, EventTypeUnfollow, EventTypeJoin, EventTypeLeave,
+			EventTypePostback, EventTypeBeacon, EventTypeDelivery, EventTypeAccountLink,
+			EventTypeMemberJoined, EventTypeMemberLeft:
+			got[event.Type]++
+		default:
+			t.Errorf("unknown event type %q", event.Type)
+		}
+	}
+	want := map[EventType]int{
+		EventTypeMessage:      6,
+		EventTypeFollow:       1,
+		EventTypeUnfollow:     1,
+		EventTypeJoin:         1,
+		EventTypeLeave:        1,
+		EventTypePostback:     1,
+		EventTypeBeacon:       2,
+		EventTypeDelivery:     1,
+		EventTypeAccountLink:  1,
+		EventTypeMemberJoined: 1,
+		EventTypeMemberLeft:   1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EventType counts %v; want %v", got, want)
+	}
+}
+
 func TestEventMarshaling(t *testing.T) {
 	testCases := &struct {
 		Events []map[string]interface{} `json:"events"`
@@ -402,6 +1006,135 @@ func TestEventMarshaling(t *testing.T) {
 	}
 }
 
+func TestEventSource(t *testing.T) {
+	var testCases = []struct {
+		Body   string
+		Want   *EventSource
+		WantID string
+	}{
+		{
+			Body: `{"type":"user","userId":"U206d25c2ea6bd87c17655609a1c37cb8"}`,
+			Want: &EventSource{
+				Type:   EventSourceTypeUser,
+				UserID: "U206d25c2ea6bd87c17655609a1c37cb8",
+			},
+			WantID: "U206d25c2ea6bd87c17655609a1c37cb8",
+		},
+		{
+			Body: `{"type":"group","groupId":"Ca56f94637cc4347f90a25382909b24b9","userId":"U206d25c2ea6bd87c17655609a1c37cb8"}`,
+			Want: &EventSource{
+				Type:    EventSourceTypeGroup,
+				UserID:  "U206d25c2ea6bd87c17655609a1c37cb8",
+				GroupID: "Ca56f94637cc4347f90a25382909b24b9",
+			},
+			WantID: "Ca56f94637cc4347f90a25382909b24b9",
+		},
+		{
+			Body: `{"type":"room","roomId":"Ra8dbf4673c4c812cd491258042226c99","userId":"U206d25c2ea6bd87c17655609a1c37cb8"}`,
+			Want: &EventSource{
+				Type:   EventSourceTypeRoom,
+				UserID: "U206d25c2ea6bd87c17655609a1c37cb8",
+				RoomID: "Ra8dbf4673c4c812cd491258042226c99",
+			},
+			WantID: "Ra8dbf4673c4c812cd491258042226c99",
+		},
+		{
+			// A user who hasn't added the bot as a friend
+			Body: `{"type":"group","groupId":"Ca56f94637cc4347f90a25382909b24b9"}`,
+			Want: &EventSource{
+				Type:    EventSourceTypeGroup,
+				GroupID: "Ca56f94637cc4347f90a25382909b24b9",
+			},
+			WantID: "Ca56f94637cc4347f90a25382909b24b9",
+		},
+		{
+			Body: `{"type":"room","roomId":"Ra8dbf4673c4c812cd491258042226c99"}`,
+			Want: &EventSource{
+				Type:   EventSourceTypeRoom,
+				RoomID: "Ra8dbf4673c4c812cd491258042226c99",
+			},
+			WantID: "Ra8dbf4673c4c812cd491258042226c99",
+		},
+	}
+	for i, tc := range testCases {
+		got := &EventSource{}
+		if err := json.Unmarshal([]byte(tc.Body), got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("EventSource %d %v; want %v", i, got, tc.Want)
+		}
+		if got.ID() != tc.WantID {
+			t.Errorf("ID %d %s; want %s", i, got.ID(), tc.WantID)
+		}
+	}
+}
+
+func TestGroupEventWithoutUserID(t *testing.T) {
+	body := []byte(`{
+    "replyToken": "nHuyWiB7yP5Zw52FIkcQobQuGDXCTA",
+    "type": "message",
+    "mode": "active",
+    "timestamp": 1462629479859,
+    "source": {"type": "group", "groupId": "Ca56f94637cc4347f90a25382909b24b9"},
+    "webhookEventId": "01FZ74A0TDDPYRVKNK77XKC3ZR",
+    "message": {"id": "325708", "type": "text", "text": "Hello, world"}
+}`)
+	event := &Event{}
+	if err := json.Unmarshal(body, event); err != nil {
+		t.Fatal(err)
+	}
+	want := &EventSource{
+		Type:    EventSourceTypeGroup,
+		GroupID: "Ca56f94637cc4347f90a25382909b24b9",
+	}
+	if !reflect.DeepEqual(event.Source, want) {
+		t.Errorf("Source %v; want %v", event.Source, want)
+	}
+	if got := event.Source.ID(); got != "Ca56f94637cc4347f90a25382909b24b9" {
+		t.Errorf("ID %s; want %s", got, "Ca56f94637cc4347f90a25382909b24b9")
+	}
+	// userId stays absent when the event is marshaled again
+	b, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "userId") {
+		t.Errorf("Marshal %s; want no userId", b)
+	}
+}
+
+func TestEventDeliveryContext(t *testing.T) {
+	body := `{"type":"message","timestamp":1462629479859,"source":{"type":"user","userId":"u206d25c2ea6bd87c17655609a1c37cb8"},"message":{"id":"325708","type":"text","text":"Hello, world"},"webhookEventId":"01FZ74A0TDDPYRVKNK77XKC3ZR","deliveryContext":{"isRedelivery":true}}`
+	event := &Event{}
+	if err := json.Unmarshal([]byte(body), event); err != nil {
+		t.Fatal(err)
+	}
+	if event.DeliveryContext == nil || !event.DeliveryContext.IsRedelivery {
+		t.Errorf("DeliveryContext %+v; want IsRedelivery", event.DeliveryContext)
+	}
+	got, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), `"deliveryContext":{"isRedelivery":true}`) {
+		t.Errorf("Event marshal %s; want the delivery context", got)
+	}
+}
+
+func TestChatIDFromSource(t *testing.T) {
+	source := &EventSource{
+		Type:   EventSourceTypeUser,
+		UserID: "U206d25c2ea6bd87c17655609a1c37cb8",
+	}
+	if got := ChatIDFromSource(source); got != "U206d25c2ea6bd87c17655609a1c37cb8" {
+		t.Errorf("ChatIDFromSource %s; want %s", got, "U206d25c2ea6bd87c17655609a1c37cb8")
+	}
+	if got := ChatIDFromSource(nil); got != "" {
+		t.Errorf("ChatIDFromSource(nil) %s; want empty", got)
+	}
+}
+
 func BenchmarkParseRequest(b *testing.B) {
 	body := []byte(webhookTestRequestBody)
 	client, err := New("testsecret", "testtoken")